@@ -2,12 +2,28 @@ package cli
 
 import (
 	"context"
+	"crypto/tls"
+	"crypto/x509"
 	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"regexp"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"syscall"
 	"time"
 
+	"github.com/liftbridge-io/liftbridge-cli/internal/config"
+	"github.com/liftbridge-io/liftbridge-cli/internal/output"
+	"github.com/liftbridge-io/liftbridge-cli/internal/publisher"
+	"github.com/liftbridge-io/liftbridge-cli/internal/supervisor"
+
 	lift "github.com/liftbridge-io/go-liftbridge/v2"
 	liftApi "github.com/liftbridge-io/liftbridge-api/go"
 	"github.com/urfave/cli/v2"
+	grpcmetadata "google.golang.org/grpc/metadata"
 )
 
 const (
@@ -21,13 +37,48 @@ const (
 )
 
 var (
-	// TODO: allow specifying multiple addresses.
 	addressFlag = &cli.StringFlag{
 		Name:    "address",
 		Aliases: []string{"a"},
-		Usage:   "connect to the endpoint specified by `ADDRESS`",
+		Usage:   "connect to the endpoint(s) specified by comma-separated `ADDRESSES`",
 		Value:   "127.0.0.1:9292",
 	}
+	contextFlag = &cli.StringFlag{
+		Name:  "context",
+		Usage: "use the named profile from the config file instead of its current-context",
+	}
+	tlsFlag = &cli.BoolFlag{
+		Name:  "tls",
+		Usage: "connect using TLS",
+	}
+	tlsCAFlag = &cli.StringFlag{
+		Name:  "tls-ca",
+		Usage: "verify the server certificate against the CA certificate in `PATH`, instead of the system roots",
+	}
+	tlsCertFlag = &cli.StringFlag{
+		Name:  "tls-cert",
+		Usage: "client certificate `PATH` to present for mutual TLS",
+	}
+	tlsKeyFlag = &cli.StringFlag{
+		Name:  "tls-key",
+		Usage: "client private key `PATH` to present for mutual TLS",
+	}
+	tlsServerNameFlag = &cli.StringFlag{
+		Name:  "tls-server-name",
+		Usage: "override the server name used to verify the server's TLS certificate",
+	}
+	tlsInsecureSkipVerifyFlag = &cli.BoolFlag{
+		Name:  "tls-insecure-skip-verify",
+		Usage: "skip server certificate verification (insecure, for testing only)",
+	}
+	authTokenFlag = &cli.StringFlag{
+		Name:  "auth-token",
+		Usage: "bearer token sent with every request",
+	}
+	authTokenFileFlag = &cli.StringFlag{
+		Name:  "auth-token-file",
+		Usage: "read the bearer token sent with every request from `PATH`",
+	}
 	streamFlag = &cli.StringFlag{
 		Name:    "stream",
 		Aliases: []string{"s"},
@@ -90,12 +141,127 @@ var (
 		Usage:   `ack policy, valid values are "leader", "all" or "none"`,
 		Value:   defaultAckPolicy,
 	}
+	cursorFileFlag = &cli.StringFlag{
+		Name:  "cursor-file",
+		Usage: "persist the last acknowledged offset to `PATH` so the subscription can resume after a restart",
+	}
+	// Note: no "o" alias, since offsetFlag already uses it for set-cursor.
+	outputFlag = &cli.StringFlag{
+		Name:  "output",
+		Usage: `output format: "text", "json", "jsonl", "yaml", or "template=<go-template>"`,
+		Value: "text",
+	}
+	sinkFlag = &cli.StringFlag{
+		Name:  "sink",
+		Usage: `where to write output: "stdout", "file:PATH", or "http(s)://URL"`,
+		Value: "stdout",
+	}
+	maxSizeFlag = &cli.IntFlag{
+		Name:  "max-size",
+		Usage: "maximum size in megabytes of a file sink before it is rotated",
+		Value: 100,
+	}
+	maxBackupsFlag = &cli.IntFlag{
+		Name:  "max-backups",
+		Usage: "maximum number of rotated file sink backups to retain",
+	}
+	maxAgeFlag = &cli.IntFlag{
+		Name:  "max-age",
+		Usage: "maximum age in days of a rotated file sink backup before it is deleted",
+	}
+	startAtFlag = &cli.StringFlag{
+		Name:  "start-at",
+		Usage: `subscription start position: "earliest", "latest", "new-only", "offset", "timestamp", or "time-delta"`,
+		Value: "earliest",
+	}
+	startOffsetFlag = &cli.Int64Flag{
+		Name:  "start-offset",
+		Usage: "offset to start at, used when --start-at=offset",
+	}
+	startTimeFlag = &cli.StringFlag{
+		Name:  "start-time",
+		Usage: "RFC3339 timestamp to start at, used when --start-at=timestamp",
+	}
+	startDeltaFlag = &cli.DurationFlag{
+		Name:  "start-delta",
+		Usage: "how far back to start at, used when --start-at=time-delta",
+	}
+	followFlag = &cli.BoolFlag{
+		Name:    "follow",
+		Aliases: []string{"f"},
+		Usage:   "keep the subscription open after catching up to the latest message",
+	}
+	countFlag = &cli.IntFlag{
+		Name:    "count",
+		Aliases: []string{"n"},
+		Usage:   "stop after receiving `N` messages",
+	}
+	keyRegexFlag = &cli.StringFlag{
+		Name:  "key-regex",
+		Usage: "only emit messages whose key matches `REGEX`",
+	}
+	valueRegexFlag = &cli.StringFlag{
+		Name:  "value-regex",
+		Usage: "only emit messages whose value matches `REGEX`",
+	}
+	replayFlag = &cli.BoolFlag{
+		Name:  "replay",
+		Usage: "bounded historical replay: subscribe to every partition in parallel between --from and --to",
+	}
+	fromFlag = &cli.StringFlag{
+		Name:  "from",
+		Usage: "RFC3339 timestamp to start replaying from, used with --replay",
+	}
+	toFlag = &cli.StringFlag{
+		Name:  "to",
+		Usage: "RFC3339 timestamp to stop replaying at, used with --replay",
+	}
+	batchSizeFlag = &cli.IntFlag{
+		Name:  "batch-size",
+		Usage: "how many messages to read ahead of the publisher",
+		Value: 100,
+	}
+	concurrencyFlag = &cli.IntFlag{
+		Name:  "concurrency",
+		Usage: "number of worker goroutines publishing concurrently",
+		Value: 1,
+	}
+	fromFileFlag = &cli.StringFlag{
+		Name:  "from-file",
+		Usage: "publish messages read from `PATH`, one per line",
+	}
+	fromStdinFlag = &cli.BoolFlag{
+		Name:  "from-stdin",
+		Usage: "publish messages read from stdin, one per line",
+	}
+	formatFlag = &cli.StringFlag{
+		Name:  "format",
+		Usage: `format of messages read via --from-file/--from-stdin: "text" or "json"`,
+		Value: "text",
+	}
+	rateFlag = &cli.Float64Flag{
+		Name:  "rate",
+		Usage: "maximum messages per second to publish, 0 for unlimited",
+	}
+	publishKeyFlag = &cli.StringFlag{
+		Name:  "key",
+		Usage: "message key to use when publishing a single --message",
+	}
+	partitionStrategyFlag = &cli.StringFlag{
+		Name:  "partition-strategy",
+		Usage: `how to route messages without an explicit partition: "round-robin", "hash-key", or "manual"`,
+		Value: "round-robin",
+	}
+	deadLetterFileFlag = &cli.StringFlag{
+		Name:  "dead-letter-file",
+		Usage: "append messages whose ack came back with an error to `PATH`",
+	}
 
 	createCommand = &cli.Command{
 		Name:    "create",
 		Aliases: []string{"c"},
 		Usage:   "Creates a stream",
-		Action:  create,
+		Action:  createAction,
 		Flags: []cli.Flag{
 			streamFlag,
 			subjectFlag,
@@ -105,37 +271,84 @@ var (
 		Name:    "subscribe",
 		Aliases: []string{"s"},
 		Usage:   "Subscribes to a stream",
-		Action:  subscribe,
+		Action:  subscribeAction,
 		Flags: []cli.Flag{
 			createStreamFlag,
 			streamFlag,
 			subjectFlag,
+			cursorFileFlag,
 		},
 	}
 	subscribeActivityStreamCommand = &cli.Command{
 		Name:    "subscribe-activity-stream",
 		Aliases: []string{"sas"},
 		Usage:   "Subscribes to the activity stream",
-		Action:  subscribeActivityStream,
+		Action:  subscribeActivityStreamAction,
+	}
+	tailCommand = &cli.Command{
+		Name:    "tail",
+		Aliases: []string{"tl"},
+		Usage:   "Tails a stream, with configurable start position and optional bounded replay",
+		Action:  tailAction,
+		Flags: []cli.Flag{
+			createStreamFlag,
+			streamFlag,
+			subjectFlag,
+			cursorFileFlag,
+			partitionFlag,
+			startAtFlag,
+			startOffsetFlag,
+			startTimeFlag,
+			startDeltaFlag,
+			followFlag,
+			countFlag,
+			keyRegexFlag,
+			valueRegexFlag,
+			replayFlag,
+			fromFlag,
+			toFlag,
+		},
+	}
+	daemonCommand = &cli.Command{
+		Name:    "daemon",
+		Aliases: []string{"d"},
+		Usage:   "Subscribes to a stream as a long-running process, reporting connection state across broker restarts",
+		Action:  daemonAction,
+		Flags: []cli.Flag{
+			createStreamFlag,
+			streamFlag,
+			subjectFlag,
+			cursorFileFlag,
+		},
 	}
 	publishCommand = &cli.Command{
 		Name:    "publish",
 		Aliases: []string{"p"},
-		Usage:   "Publishes to a stream",
-		Action:  publish,
+		Usage:   "Publishes to a stream, optionally at high throughput via --from-file/--from-stdin",
+		Action:  publishAction,
 		Flags: []cli.Flag{
 			messageFlag,
+			publishKeyFlag,
 			createStreamFlag,
 			streamFlag,
 			subjectFlag,
 			ackPolicyFlag,
+			partitionFlag,
+			partitionStrategyFlag,
+			batchSizeFlag,
+			concurrencyFlag,
+			fromFileFlag,
+			fromStdinFlag,
+			formatFlag,
+			rateFlag,
+			deadLetterFileFlag,
 		},
 	}
 	setReadonlyCommand = &cli.Command{
 		Name:    "set-readonly",
 		Aliases: []string{"r"},
 		Usage:   "Sets a stream as readonly",
-		Action:  setReadonly,
+		Action:  setReadonlyAction,
 		Flags: []cli.Flag{
 			createStreamFlag,
 			streamFlag,
@@ -148,7 +361,7 @@ var (
 		Name:    "pause",
 		Aliases: []string{"u"},
 		Usage:   "Pauses a stream",
-		Action:  pause,
+		Action:  pauseAction,
 		Flags: []cli.Flag{
 			createStreamFlag,
 			streamFlag,
@@ -161,7 +374,7 @@ var (
 		Name:    "delete",
 		Aliases: []string{"d"},
 		Usage:   "Deletes a stream",
-		Action:  delete,
+		Action:  deleteAction,
 		Flags: []cli.Flag{
 			createStreamFlag,
 			streamFlag,
@@ -172,13 +385,13 @@ var (
 		Name:    "metadata",
 		Aliases: []string{"m"},
 		Usage:   "Fetches metadata",
-		Action:  metadata,
+		Action:  metadataAction,
 	}
 	partitionMetadataCommand = &cli.Command{
 		Name:    "partition-metadata",
 		Aliases: []string{"t"},
 		Usage:   "Fetches a partition's metadata",
-		Action:  partitionMetadata,
+		Action:  partitionMetadataAction,
 		Flags: []cli.Flag{
 			createStreamFlag,
 			streamFlag,
@@ -190,7 +403,7 @@ var (
 		Name:    "set-cursor",
 		Aliases: []string{"e"},
 		Usage:   "Sets a cursor's offset",
-		Action:  setCursor,
+		Action:  setCursorAction,
 		Flags: []cli.Flag{
 			createStreamFlag,
 			streamFlag,
@@ -204,7 +417,7 @@ var (
 		Name:    "fetch-cursor",
 		Aliases: []string{"f"},
 		Usage:   "Fetches a cursor's offset",
-		Action:  fetchCursor,
+		Action:  fetchCursorAction,
 		Flags: []cli.Flag{
 			streamFlag,
 			cursorIDFlag,
@@ -213,15 +426,201 @@ var (
 	}
 )
 
-func connectToEndpoint(address string) (lift.Client, error) {
-	client, err := lift.Connect([]string{address})
+// connectionParams holds the resolved address list, TLS configuration, and
+// auth token for a connection, merged from a --context profile and flag
+// overrides by resolveConnectionParams.
+type connectionParams struct {
+	addresses []string
+	tlsConfig *tls.Config
+	authToken string
+}
+
+// resolveConnectionParams merges the config file context selected by
+// --context (or its current-context, if --context is unset) with flag
+// overrides, which always take precedence over the profile.
+func resolveConnectionParams(c *cli.Context) (connectionParams, error) {
+	cfg, err := config.Load()
 	if err != nil {
-		return nil, fmt.Errorf("connection failed with address %v: %w", address, err)
+		return connectionParams{}, err
+	}
+
+	profile, ok := cfg.Context(c.String(contextFlag.Name))
+	if !ok && c.IsSet(contextFlag.Name) {
+		return connectionParams{}, fmt.Errorf("no such context %q in config file", c.String(contextFlag.Name))
+	}
+
+	conn := connectionParams{
+		addresses: splitAddresses(c.String(addressFlag.Name)),
+	}
+	if !c.IsSet(addressFlag.Name) && len(profile.Addresses) > 0 {
+		conn.addresses = profile.Addresses
+	}
+
+	tlsEnabled := c.Bool(tlsFlag.Name)
+	if !c.IsSet(tlsFlag.Name) && profile.TLS != nil {
+		tlsEnabled = profile.TLS.Enabled
+	}
+	if tlsEnabled {
+		tlsConfig, err := buildTLSConfig(c, profile.TLS)
+		if err != nil {
+			return connectionParams{}, err
+		}
+		conn.tlsConfig = tlsConfig
+	}
+
+	authToken, err := resolveAuthToken(c, profile)
+	if err != nil {
+		return connectionParams{}, err
+	}
+	conn.authToken = authToken
+
+	return conn, nil
+}
+
+// splitAddresses splits a comma-separated --address value into its
+// individual addresses, trimming whitespace around each one (so
+// "a:1, b:2" doesn't produce a leading-space address that fails to dial)
+// and dropping any that are empty after trimming.
+func splitAddresses(raw string) []string {
+	var addresses []string
+	for _, addr := range strings.Split(raw, ",") {
+		if addr = strings.TrimSpace(addr); addr != "" {
+			addresses = append(addresses, addr)
+		}
+	}
+	return addresses
+}
+
+// buildTLSConfig builds a *tls.Config from the --tls-* flags, falling back
+// to profile for any flag left unset.
+func buildTLSConfig(c *cli.Context, profile *config.TLS) (*tls.Config, error) {
+	tlsConfig := &tls.Config{}
+
+	ca := c.String(tlsCAFlag.Name)
+	if ca == "" && profile != nil {
+		ca = profile.CA
+	}
+	if ca != "" {
+		pem, err := os.ReadFile(ca)
+		if err != nil {
+			return nil, fmt.Errorf("reading --tls-ca: %w", err)
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("no certificates found in --tls-ca %v", ca)
+		}
+		tlsConfig.RootCAs = pool
+	}
+
+	cert := c.String(tlsCertFlag.Name)
+	key := c.String(tlsKeyFlag.Name)
+	if cert == "" && key == "" && profile != nil {
+		cert, key = profile.Cert, profile.Key
+	}
+	if cert != "" || key != "" {
+		pair, err := tls.LoadX509KeyPair(cert, key)
+		if err != nil {
+			return nil, fmt.Errorf("loading --tls-cert/--tls-key: %w", err)
+		}
+		tlsConfig.Certificates = []tls.Certificate{pair}
+	}
+
+	serverName := c.String(tlsServerNameFlag.Name)
+	if serverName == "" && profile != nil {
+		serverName = profile.ServerName
+	}
+	tlsConfig.ServerName = serverName
+
+	insecureSkipVerify := c.Bool(tlsInsecureSkipVerifyFlag.Name)
+	if !c.IsSet(tlsInsecureSkipVerifyFlag.Name) && profile != nil {
+		insecureSkipVerify = profile.InsecureSkipVerify
+	}
+	tlsConfig.InsecureSkipVerify = insecureSkipVerify
+
+	return tlsConfig, nil
+}
+
+// resolveAuthToken resolves the bearer token to send with every request,
+// preferring --auth-token, then --auth-token-file, then the profile's
+// auth-token-file, then the profile's auth-token.
+func resolveAuthToken(c *cli.Context, profile config.Context) (string, error) {
+	if token := c.String(authTokenFlag.Name); token != "" {
+		return token, nil
+	}
+
+	path := c.String(authTokenFileFlag.Name)
+	if path == "" {
+		path = profile.AuthTokenFile
+	}
+	if path != "" {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return "", fmt.Errorf("reading --auth-token-file: %w", err)
+		}
+		return strings.TrimSpace(string(data)), nil
+	}
+
+	return profile.AuthToken, nil
+}
+
+func connectToEndpoint(conn connectionParams) (lift.Client, error) {
+	var opts []lift.ClientOption
+	if conn.tlsConfig != nil {
+		opts = append(opts, lift.TLSConfig(conn.tlsConfig))
+	}
+
+	client, err := lift.Connect(conn.addresses, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("connection failed with addresses %v: %w", conn.addresses, err)
 	}
 
 	return client, nil
 }
 
+// authContext attaches conn's bearer token, if any, to ctx as outgoing
+// gRPC metadata so it is sent with every request made using ctx.
+func authContext(ctx context.Context, conn connectionParams) context.Context {
+	if conn.authToken == "" {
+		return ctx
+	}
+	return grpcmetadata.AppendToOutgoingContext(ctx, "authorization", "Bearer "+conn.authToken)
+}
+
+// connectForCommand resolves connection parameters from c and connects, for the
+// common case of a one-shot command that makes a single connection.
+func connectForCommand(c *cli.Context) (lift.Client, connectionParams, error) {
+	conn, err := resolveConnectionParams(c)
+	if err != nil {
+		return nil, connectionParams{}, err
+	}
+
+	client, err := connectToEndpoint(conn)
+	if err != nil {
+		return nil, connectionParams{}, err
+	}
+
+	return client, conn, nil
+}
+
+// newOutputWriter builds an output.Writer from the global --output and
+// --sink flags.
+func newOutputWriter(params Params) (*output.Writer, error) {
+	writer, err := output.NewWriter(
+		params.String(outputFlag.Name),
+		params.String(sinkFlag.Name),
+		output.FileSinkOptions{
+			MaxSize:    params.Int(maxSizeFlag.Name),
+			MaxBackups: params.Int(maxBackupsFlag.Name),
+			MaxAge:     params.Int(maxAgeFlag.Name),
+		},
+	)
+	if err != nil {
+		return nil, fmt.Errorf("building output writer: %w", err)
+	}
+
+	return writer, nil
+}
+
 func ensureStreamCreated(ctx context.Context, client lift.Client, streamName, subjectName string) error {
 	if len(subjectName) == 0 {
 		subjectName = streamName
@@ -235,83 +634,491 @@ func ensureStreamCreated(ctx context.Context, client lift.Client, streamName, su
 	return nil
 }
 
-// subscribeToStream subscribes to a channel and blocks until an error occurs.
+// signalContext returns a context that is canceled on SIGINT or SIGTERM,
+// used as the root context for long-running commands so that Ctrl-C
+// triggers a graceful shutdown (including cursor flushing) rather than an
+// abrupt exit.
+func signalContext() (context.Context, context.CancelFunc) {
+	return signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+}
+
+// newStreamSupervisor builds a supervisor.Supervisor that subscribes to
+// streamName with the given subscription options, creating the stream
+// first if createStream is set, and resumes from cursorPath across
+// reconnects. An empty cursorPath disables cursor persistence.
+func newStreamSupervisor(
+	ctx context.Context,
+	streamName, subjectName string,
+	partition int32,
+	handler func(*lift.Message),
+	conn connectionParams,
+	createStream bool,
+	cursorPath string,
+	opts ...lift.SubscriptionOption,
+) (*supervisor.Supervisor, error) {
+	if createStream {
+		client, err := connectToEndpoint(conn)
+		if err != nil {
+			return nil, fmt.Errorf("stream subscription failed: %w", err)
+		}
+
+		createCtx, cancel := context.WithTimeout(ctx, timeoutDuration)
+		defer cancel()
+
+		if err := ensureStreamCreated(createCtx, client, streamName, subjectName); err != nil {
+			return nil, err
+		}
+	}
+
+	sup := supervisor.New(func() (lift.Client, error) {
+		return connectToEndpoint(conn)
+	}, cursorPath)
+
+	sup.Register(supervisor.Subscription{
+		Stream:    streamName,
+		Partition: partition,
+		Handler:   handler,
+		Options:   opts,
+	})
+
+	return sup, nil
+}
+
+// subscribeToStream subscribes to a stream and blocks until ctx is
+// canceled, automatically reconnecting with backoff and resuming from the
+// last acknowledged offset if the broker connection is lost.
 func subscribeToStream(
+	ctx context.Context,
 	streamName, subjectName string,
 	handler func(*lift.Message),
-	endPointAddress string,
+	conn connectionParams,
 	createStream bool,
+	cursorPath string,
 ) error {
-	client, err := connectToEndpoint(endPointAddress)
+	sup, err := newStreamSupervisor(ctx, streamName, subjectName, 0, handler, conn, createStream, cursorPath)
 	if err != nil {
-		return fmt.Errorf("stream subscription failed: %w", err)
+		return err
+	}
+
+	if err := sup.Run(ctx); err != nil && ctx.Err() == nil {
+		return fmt.Errorf("stream subscription failed for stream %v: %w", streamName, err)
+	}
+
+	return nil
+}
+
+func createAction(c *cli.Context) error {
+	client, conn, err := connectForCommand(c)
+	if err != nil {
+		return fmt.Errorf("creation failed: %w", err)
 	}
 
-	ctx, cancel := context.WithTimeout(context.Background(), timeoutDuration)
+	ctx, cancel := context.WithTimeout(authContext(context.Background(), conn), timeoutDuration)
 	defer cancel()
 
-	if createStream {
-		if err := ensureStreamCreated(ctx, client, streamName, subjectName); err != nil {
-			return err
+	return create(ctx, client, c)
+}
+
+// create creates the stream named by params' --stream/--subject flags.
+func create(ctx context.Context, client lift.Client, params Params) error {
+	streamName := params.String(streamFlag.Name)
+	subjectName := params.String(subjectFlag.Name)
+
+	if len(subjectName) == 0 {
+		subjectName = streamName
+	}
+
+	if err := client.CreateStream(ctx, subjectName, streamName); err != nil {
+		return fmt.Errorf("stream creation failed for stream %v: %w", streamName, err)
+	}
+
+	return nil
+}
+
+// messageHandler returns a handler that renders each message as an
+// output.MessageRecord and writes it through writer.
+func messageHandler(writer *output.Writer, streamName string) func(*lift.Message) {
+	return func(message *lift.Message) {
+		err := writer.WriteRecord(output.MessageRecord{
+			Stream:    streamName,
+			Partition: message.Partition(),
+			Offset:    message.Offset(),
+			Key:       message.Key(),
+			Value:     message.Value(),
+		})
+		if err != nil {
+			fmt.Printf("writing message record failed: %v\n", err)
 		}
 	}
+}
 
-	errC := make(chan error)
+func subscribeAction(c *cli.Context) error {
+	conn, err := resolveConnectionParams(c)
+	if err != nil {
+		return fmt.Errorf("stream subscription failed: %w", err)
+	}
 
-	ctx, cancel = context.WithCancel(context.Background())
+	ctx, cancel := signalContext()
 	defer cancel()
+	ctx = authContext(ctx, conn)
 
-	err = client.Subscribe(ctx, streamName, func(m *lift.Message, err error) {
+	return subscribe(ctx, conn, c)
+}
+
+// subscribe subscribes to the stream named by params' --stream/--subject
+// flags, reconnecting for as long as ctx remains uncanceled.
+func subscribe(ctx context.Context, conn connectionParams, params Params) error {
+	streamName := params.String(streamFlag.Name)
+	subjectName := params.String(subjectFlag.Name)
+
+	writer, err := newOutputWriter(params)
+	if err != nil {
+		return fmt.Errorf("stream subscription failed: %w", err)
+	}
+	defer writer.Close()
+
+	return subscribeToStream(ctx, streamName, subjectName, messageHandler(writer, streamName),
+		conn, params.Bool(createStreamFlag.Name), params.String(cursorFileFlag.Name))
+}
+
+// startPositionOption builds the subscription start position from the
+// --start-at flag and its companions.
+func startPositionOption(params Params) (lift.SubscriptionOption, error) {
+	switch params.String(startAtFlag.Name) {
+	case "earliest":
+		return lift.StartAtEarliestReceived(), nil
+	case "latest":
+		return lift.StartAtLatestReceived(), nil
+	case "new-only":
+		return lift.StartAtNewOnly(), nil
+	case "offset":
+		return lift.StartAtOffset(params.Int64(startOffsetFlag.Name)), nil
+	case "timestamp":
+		t, err := time.Parse(time.RFC3339, params.String(startTimeFlag.Name))
 		if err != nil {
-			errC <- err
-			return
+			return nil, fmt.Errorf("invalid --start-time: %w", err)
+		}
+		return lift.StartAtTime(t), nil
+	case "time-delta":
+		return lift.StartAtTimeDelta(params.Duration(startDeltaFlag.Name)), nil
+	default:
+		return nil, fmt.Errorf("invalid --start-at: %v", params.String(startAtFlag.Name))
+	}
+}
+
+// messageFilter builds a predicate from --key-regex/--value-regex that
+// reports whether a message should be emitted. A nil pattern always
+// matches.
+func messageFilter(params Params) (func(*lift.Message) bool, error) {
+	var keyRe, valueRe *regexp.Regexp
+
+	if pattern := params.String(keyRegexFlag.Name); pattern != "" {
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			return nil, fmt.Errorf("invalid --key-regex: %w", err)
+		}
+		keyRe = re
+	}
+
+	if pattern := params.String(valueRegexFlag.Name); pattern != "" {
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			return nil, fmt.Errorf("invalid --value-regex: %w", err)
+		}
+		valueRe = re
+	}
+
+	return func(m *lift.Message) bool {
+		if keyRe != nil && !keyRe.Match(m.Key()) {
+			return false
+		}
+		if valueRe != nil && !valueRe.Match(m.Value()) {
+			return false
 		}
+		return true
+	}, nil
+}
 
+// countingHandler wraps handler so it cancels the subscription once limit
+// messages have been delivered. A limit <= 0 disables the count.
+func countingHandler(handler func(*lift.Message), limit int, cancel context.CancelFunc) func(*lift.Message) {
+	if limit <= 0 {
+		return handler
+	}
+
+	var n int32
+	return func(m *lift.Message) {
 		handler(m)
-		// TODO: allow setting subscription options.
-	}, lift.StartAtEarliestReceived())
+		if int(atomic.AddInt32(&n, 1)) >= limit {
+			cancel()
+		}
+	}
+}
+
+// tailAction runs tail under a signal-canceled root context. With --replay,
+// it instead runs replay, a bounded historical replay between --from and
+// --to.
+func tailAction(c *cli.Context) error {
+	conn, err := resolveConnectionParams(c)
 	if err != nil {
-		return fmt.Errorf("stream subscription failed for stream %v: %w", streamName, err)
+		return fmt.Errorf("tail failed: %w", err)
+	}
+
+	ctx, cancel := signalContext()
+	defer cancel()
+	ctx = authContext(ctx, conn)
+
+	if c.Bool(replayFlag.Name) {
+		return replay(ctx, conn, c)
 	}
 
-	return <-errC
+	return tail(ctx, cancel, conn, c)
 }
 
-func create(c *cli.Context) error {
-	client, err := connectToEndpoint(c.String(addressFlag.Name))
+// tail subscribes to a stream with a configurable start position,
+// optional client-side key/value filtering, and an optional message
+// count limit. cancel is called once --count messages have been
+// delivered, if set.
+func tail(ctx context.Context, cancel context.CancelFunc, conn connectionParams, params Params) error {
+	streamName := params.String(streamFlag.Name)
+	subjectName := params.String(subjectFlag.Name)
+
+	startOpt, err := startPositionOption(params)
 	if err != nil {
-		return fmt.Errorf("creation failed: %w", err)
+		return fmt.Errorf("tail failed: %w", err)
+	}
+
+	filter, err := messageFilter(params)
+	if err != nil {
+		return fmt.Errorf("tail failed: %w", err)
+	}
+
+	writer, err := newOutputWriter(params)
+	if err != nil {
+		return fmt.Errorf("tail failed: %w", err)
 	}
+	defer writer.Close()
 
-	ctx, cancel := context.WithTimeout(context.Background(), timeoutDuration)
+	var partition int32
+	opts := []lift.SubscriptionOption{startOpt}
+	if params.IsSet(partitionFlag.Name) {
+		partition = int32(params.Int(partitionFlag.Name))
+		opts = append(opts, lift.Partition(partition))
+	}
+	if !params.Bool(followFlag.Name) {
+		opts = append(opts, lift.StopAtLatestReceived())
+	}
+
+	emit := messageHandler(writer, streamName)
+	handler := countingHandler(func(message *lift.Message) {
+		if filter(message) {
+			emit(message)
+		}
+	}, params.Int(countFlag.Name), cancel)
+
+	sup, err := newStreamSupervisor(ctx, streamName, subjectName, partition, handler,
+		conn, params.Bool(createStreamFlag.Name), params.String(cursorFileFlag.Name), opts...)
+	if err != nil {
+		return fmt.Errorf("tail failed: %w", err)
+	}
+
+	if err := sup.Run(ctx); err != nil && ctx.Err() == nil {
+		return fmt.Errorf("tail failed: %w", err)
+	}
+
+	return nil
+}
+
+// replay subscribes to every partition of a stream in parallel, bounded
+// between --from and --to, and stops each partition once its offset
+// crosses the --to boundary.
+func replay(ctx context.Context, conn connectionParams, params Params) error {
+	streamName := params.String(streamFlag.Name)
+
+	from, err := time.Parse(time.RFC3339, params.String(fromFlag.Name))
+	if err != nil {
+		return fmt.Errorf("replay failed: invalid --from: %w", err)
+	}
+
+	to, err := time.Parse(time.RFC3339, params.String(toFlag.Name))
+	if err != nil {
+		return fmt.Errorf("replay failed: invalid --to: %w", err)
+	}
+
+	filter, err := messageFilter(params)
+	if err != nil {
+		return fmt.Errorf("replay failed: %w", err)
+	}
+
+	writer, err := newOutputWriter(params)
+	if err != nil {
+		return fmt.Errorf("replay failed: %w", err)
+	}
+	defer writer.Close()
+
+	client, err := connectToEndpoint(conn)
+	if err != nil {
+		return fmt.Errorf("replay failed: %w", err)
+	}
+	defer client.Close()
+
+	metaCtx, cancelMeta := context.WithTimeout(authContext(context.Background(), conn), timeoutDuration)
+	metadata, err := client.FetchMetadata(metaCtx, lift.Streams([]string{streamName}))
+	cancelMeta()
+	if err != nil {
+		return fmt.Errorf("replay failed: %w", err)
+	}
+
+	streams := metadata.Streams()
+	if len(streams) == 0 {
+		return fmt.Errorf("replay failed: stream %v not found", streamName)
+	}
+
+	var (
+		wg       sync.WaitGroup
+		writerMu sync.Mutex
+	)
+	errC := make(chan error, len(streams[0].Partitions()))
+
+	for _, pv := range streams[0].Partitions() {
+		pv := pv
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			partitionClient, err := connectToEndpoint(conn)
+			if err != nil {
+				errC <- err
+				return
+			}
+			defer partitionClient.Close()
+
+			done := make(chan error, 1)
+			err = partitionClient.Subscribe(ctx, streamName, func(m *lift.Message, err error) {
+				if err != nil {
+					done <- err
+					return
+				}
+
+				if !filter(m) {
+					return
+				}
+
+				writerMu.Lock()
+				writeErr := writer.WriteRecord(output.MessageRecord{
+					Stream:    streamName,
+					Partition: m.Partition(),
+					Offset:    m.Offset(),
+					Key:       m.Key(),
+					Value:     m.Value(),
+				})
+				writerMu.Unlock()
+				if writeErr != nil {
+					fmt.Printf("writing message record failed: %v\n", writeErr)
+				}
+			}, lift.StartAtTime(from), lift.StopAtTime(to), lift.Partition(pv.ID()))
+			if err != nil {
+				errC <- err
+				return
+			}
+
+			select {
+			case err := <-done:
+				if err != nil && err != io.EOF {
+					errC <- err
+				}
+			case <-ctx.Done():
+			}
+		}()
+	}
+
+	wg.Wait()
+	close(errC)
+
+	for err := range errC {
+		if err != nil {
+			return fmt.Errorf("replay failed: %w", err)
+		}
+	}
+
+	return nil
+}
+
+func daemonAction(c *cli.Context) error {
+	conn, err := resolveConnectionParams(c)
+	if err != nil {
+		return fmt.Errorf("daemon failed: %w", err)
+	}
+
+	ctx, cancel := signalContext()
 	defer cancel()
+	ctx = authContext(ctx, conn)
 
-	streamName := c.String(streamFlag.Name)
-	subjectName := c.String(subjectFlag.Name)
+	return daemon(ctx, conn, c)
+}
 
-	if len(subjectName) == 0 {
-		subjectName = streamName
+// daemon runs the same resilient subscription as subscribe, but surfaces
+// the supervisor's connection lifecycle so the process is suitable for
+// running unattended (e.g. under a service manager) across broker
+// restarts.
+func daemon(ctx context.Context, conn connectionParams, params Params) error {
+	streamName := params.String(streamFlag.Name)
+	subjectName := params.String(subjectFlag.Name)
+
+	writer, err := newOutputWriter(params)
+	if err != nil {
+		return fmt.Errorf("daemon failed: %w", err)
 	}
+	defer writer.Close()
 
-	err = client.CreateStream(ctx, subjectName, streamName)
+	sup, err := newStreamSupervisor(ctx, streamName, subjectName, 0, messageHandler(writer, streamName),
+		conn, params.Bool(createStreamFlag.Name), params.String(cursorFileFlag.Name))
 	if err != nil {
-		return fmt.Errorf("stream creation failed for stream %v: %w", streamName, err)
+		return fmt.Errorf("daemon failed: %w", err)
+	}
+
+	sup.OnDisconnected = func(err error) {
+		fmt.Printf("disconnected from %v: %v, reconnecting...\n", conn.addresses, err)
+	}
+	sup.OnReconnected = func() {
+		fmt.Printf("reconnected to %v\n", conn.addresses)
+	}
+	sup.OnClosed = func() {
+		fmt.Printf("shutting down\n")
+	}
+
+	if err := sup.Run(ctx); err != nil && ctx.Err() == nil {
+		return fmt.Errorf("daemon failed: %w", err)
 	}
 
 	return nil
 }
 
-func subscribe(c *cli.Context) error {
-	streamName := c.String(streamFlag.Name)
-	subjectName := c.String(subjectFlag.Name)
+func subscribeActivityStreamAction(c *cli.Context) error {
+	conn, err := resolveConnectionParams(c)
+	if err != nil {
+		return fmt.Errorf("stream subscription failed: %w", err)
+	}
 
-	return subscribeToStream(streamName, subjectName, func(message *lift.Message) {
-		fmt.Printf("Received message with data: %v, offset: %v\n", string(message.Value()), message.Offset())
-	}, c.String(addressFlag.Name), c.Bool(createStreamFlag.Name))
+	ctx, cancel := signalContext()
+	defer cancel()
+	ctx = authContext(ctx, conn)
+
+	return subscribeActivityStream(ctx, conn, c)
 }
 
-func subscribeActivityStream(c *cli.Context) error {
-	return subscribeToStream(activityStreamName, "", func(message *lift.Message) {
+// subscribeActivityStream subscribes to the broker's internal activity
+// stream, writing each event as an output.ActivityRecord through the
+// --output/--sink writer.
+func subscribeActivityStream(ctx context.Context, conn connectionParams, params Params) error {
+	writer, err := newOutputWriter(params)
+	if err != nil {
+		return fmt.Errorf("stream subscription failed: %w", err)
+	}
+	defer writer.Close()
+
+	return subscribeToStream(ctx, activityStreamName, "", func(message *lift.Message) {
 		var se liftApi.ActivityStreamEvent
 		err := se.Unmarshal(message.Value())
 		if err != nil {
@@ -338,12 +1145,14 @@ func subscribeActivityStream(c *cli.Context) error {
 			activityStr = "unknown activity"
 		}
 
-		fmt.Printf("Received activity stream message: op: %v, %v, offset: %v\n",
-			se.Op,
-			activityStr,
-			message.Offset(),
-		)
-	}, c.String(addressFlag.Name), false)
+		if err := writer.WriteRecord(output.ActivityRecord{
+			Op:     se.Op.String(),
+			Detail: activityStr,
+			Offset: message.Offset(),
+		}); err != nil {
+			fmt.Printf("writing activity record failed: %v\n", err)
+		}
+	}, conn, false, "")
 }
 
 func ackPolicyStringToAckPolicy(ackPolicy string) (lift.MessageOption, error) {
@@ -359,37 +1168,163 @@ func ackPolicyStringToAckPolicy(ackPolicy string) (lift.MessageOption, error) {
 	}
 }
 
-func publish(c *cli.Context) error {
-	client, err := connectToEndpoint(c.String(addressFlag.Name))
+// partitionStrategyFromFlag parses the --partition-strategy flag.
+func partitionStrategyFromFlag(params Params) (publisher.PartitionStrategy, error) {
+	switch params.String(partitionStrategyFlag.Name) {
+	case "round-robin":
+		return publisher.PartitionRoundRobin, nil
+	case "hash-key":
+		return publisher.PartitionHashKey, nil
+	case "manual":
+		return publisher.PartitionManual, nil
+	default:
+		return 0, fmt.Errorf("invalid --partition-strategy: %v", params.String(partitionStrategyFlag.Name))
+	}
+}
+
+// publishMessageSource builds the channel of messages to publish from
+// --from-file, --from-stdin, or a single --message/--key pair, along with
+// a channel that reports any error encountered reading them.
+func publishMessageSource(params Params) (<-chan publisher.Message, <-chan error, error) {
+	batchSize := params.Int(batchSizeFlag.Name)
+	format := params.String(formatFlag.Name)
+
+	switch {
+	case params.String(fromFileFlag.Name) != "":
+		f, err := os.Open(params.String(fromFileFlag.Name))
+		if err != nil {
+			return nil, nil, fmt.Errorf("opening --from-file: %w", err)
+		}
+
+		messages, errC := publisher.ReadMessages(f, format, batchSize)
+		return messages, closeOnDrain(errC, f), nil
+	case params.Bool(fromStdinFlag.Name):
+		messages, errC := publisher.ReadMessages(os.Stdin, format, batchSize)
+		return messages, errC, nil
+	default:
+		var key []byte
+		if k := params.String(publishKeyFlag.Name); k != "" {
+			key = []byte(k)
+		}
+
+		out := make(chan publisher.Message, 1)
+		out <- publisher.Message{Key: key, Value: []byte(params.String(messageFlag.Name))}
+		close(out)
+
+		errC := make(chan error, 1)
+		close(errC)
+
+		return out, errC, nil
+	}
+}
+
+// closeOnDrain closes c once errC is drained, forwarding its value on the
+// returned channel.
+func closeOnDrain(errC <-chan error, c io.Closer) <-chan error {
+	out := make(chan error, 1)
+
+	go func() {
+		err := <-errC
+		c.Close()
+		out <- err
+	}()
+
+	return out
+}
+
+// printPublishSummary prints a running publish summary every second until
+// ctx is canceled.
+func printPublishSummary(ctx context.Context, stats *publisher.Stats) {
+	ticker := time.NewTicker(time.Second)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			fmt.Printf("%v\n", stats.Snapshot())
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+func publishAction(c *cli.Context) error {
+	client, conn, err := connectForCommand(c)
 	if err != nil {
 		return fmt.Errorf("publication failed: %w", err)
 	}
 
-	ctx, cancel := context.WithTimeout(context.Background(), timeoutDuration)
+	ctx, cancel := signalContext()
 	defer cancel()
+	ctx = authContext(ctx, conn)
 
-	streamName := c.String(streamFlag.Name)
-	subjectName := c.String(subjectFlag.Name)
+	return publish(ctx, cancel, client, c)
+}
 
-	if c.Bool(createStreamFlag.Name) {
-		if err := ensureStreamCreated(ctx, client, streamName, subjectName); err != nil {
+// publish publishes messages from params' --from-file/--from-stdin/--message
+// source until the source is exhausted or ctx is canceled. cancel stops the
+// running summary printer once publishing completes.
+func publish(ctx context.Context, cancel context.CancelFunc, client lift.Client, params Params) error {
+	streamName := params.String(streamFlag.Name)
+	subjectName := params.String(subjectFlag.Name)
+
+	if params.Bool(createStreamFlag.Name) {
+		createCtx, cancelCreate := context.WithTimeout(ctx, timeoutDuration)
+		err := ensureStreamCreated(createCtx, client, streamName, subjectName)
+		cancelCreate()
+		if err != nil {
 			return err
 		}
 	}
 
-	data := []byte(c.String(messageFlag.Name))
-	ackPolicyOption, err := ackPolicyStringToAckPolicy(c.String(ackPolicyFlag.Name))
+	ackPolicyOption, err := ackPolicyStringToAckPolicy(params.String(ackPolicyFlag.Name))
 	if err != nil {
 		return fmt.Errorf("publication failed: %w", err)
 	}
 
-	_, err = client.Publish(
-		ctx,
-		streamName,
-		data,
-		ackPolicyOption,
-	)
-	if err != nil && err != lift.ErrStreamExists {
+	strategy, err := partitionStrategyFromFlag(params)
+	if err != nil {
+		return fmt.Errorf("publication failed: %w", err)
+	}
+
+	var deadLetter io.Writer
+	if path := params.String(deadLetterFileFlag.Name); path != "" {
+		f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+		if err != nil {
+			return fmt.Errorf("publication failed: opening --dead-letter-file: %w", err)
+		}
+		defer f.Close()
+		deadLetter = f
+	}
+
+	messages, readErrC, err := publishMessageSource(params)
+	if err != nil {
+		return fmt.Errorf("publication failed: %w", err)
+	}
+
+	pub := publisher.New(client, publisher.Options{
+		Stream:            streamName,
+		Concurrency:       params.Int(concurrencyFlag.Name),
+		RatePerSecond:     params.Float64(rateFlag.Name),
+		PartitionStrategy: strategy,
+		ManualPartition:   int32(params.Int(partitionFlag.Name)),
+		AckPolicy:         ackPolicyOption,
+		DeadLetterWriter:  deadLetter,
+	})
+
+	summaryDone := make(chan struct{})
+	go func() {
+		defer close(summaryDone)
+		printPublishSummary(ctx, pub.Stats())
+	}()
+
+	pub.Run(ctx, messages)
+	cancel()
+	<-summaryDone
+
+	fmt.Printf("final report: %v\n", pub.Stats().Snapshot())
+
+	if err := <-readErrC; err != nil {
 		return fmt.Errorf("publication failed: %w", err)
 	}
 
@@ -404,27 +1339,31 @@ func intToInt32Slice(slice []int) []int32 {
 	return result
 }
 
-func setReadonly(c *cli.Context) error {
-	client, err := connectToEndpoint(c.String(addressFlag.Name))
+func setReadonlyAction(c *cli.Context) error {
+	client, conn, err := connectForCommand(c)
 	if err != nil {
 		return fmt.Errorf("set readonly failed: %w", err)
 	}
 
-	ctx, cancel := context.WithTimeout(context.Background(), timeoutDuration)
+	ctx, cancel := context.WithTimeout(authContext(context.Background(), conn), timeoutDuration)
 	defer cancel()
 
-	streamName := c.String(streamFlag.Name)
-	subjectName := c.String(subjectFlag.Name)
+	return setReadonly(ctx, client, c)
+}
+
+func setReadonly(ctx context.Context, client lift.Client, params Params) error {
+	streamName := params.String(streamFlag.Name)
+	subjectName := params.String(subjectFlag.Name)
 
-	if c.Bool(createStreamFlag.Name) {
+	if params.Bool(createStreamFlag.Name) {
 		if err := ensureStreamCreated(ctx, client, streamName, subjectName); err != nil {
 			return err
 		}
 	}
 
-	readonly := c.Bool(readonlyFlag.Name)
-	partitions := c.IntSlice(partitionsFlag.Name)
-	err = client.SetStreamReadonly(
+	readonly := params.Bool(readonlyFlag.Name)
+	partitions := params.IntSlice(partitionsFlag.Name)
+	err := client.SetStreamReadonly(
 		ctx,
 		streamName,
 		lift.Readonly(readonly),
@@ -437,33 +1376,37 @@ func setReadonly(c *cli.Context) error {
 	return nil
 }
 
-func pause(c *cli.Context) error {
-	client, err := connectToEndpoint(c.String(addressFlag.Name))
+func pauseAction(c *cli.Context) error {
+	client, conn, err := connectForCommand(c)
 	if err != nil {
 		return fmt.Errorf("pause failed: %w", err)
 	}
 
-	ctx, cancel := context.WithTimeout(context.Background(), timeoutDuration)
+	ctx, cancel := context.WithTimeout(authContext(context.Background(), conn), timeoutDuration)
 	defer cancel()
 
-	streamName := c.String(streamFlag.Name)
-	subjectName := c.String(subjectFlag.Name)
+	return pause(ctx, client, c)
+}
+
+func pause(ctx context.Context, client lift.Client, params Params) error {
+	streamName := params.String(streamFlag.Name)
+	subjectName := params.String(subjectFlag.Name)
 
-	if c.Bool(createStreamFlag.Name) {
+	if params.Bool(createStreamFlag.Name) {
 		if err := ensureStreamCreated(ctx, client, streamName, subjectName); err != nil {
 			return err
 		}
 	}
 
 	var opts []lift.PauseOption
-	if c.Bool(resumeAllFlag.Name) {
+	if params.Bool(resumeAllFlag.Name) {
 		opts = append(opts, lift.ResumeAll())
 	}
 
-	partitions := c.IntSlice(partitionsFlag.Name)
+	partitions := params.IntSlice(partitionsFlag.Name)
 	opts = append(opts, lift.PausePartitions(intToInt32Slice(partitions)...))
 
-	err = client.PauseStream(
+	err := client.PauseStream(
 		ctx,
 		streamName,
 		opts...,
@@ -475,25 +1418,30 @@ func pause(c *cli.Context) error {
 	return nil
 }
 
-func delete(c *cli.Context) error {
-	client, err := connectToEndpoint(c.String(addressFlag.Name))
+func deleteAction(c *cli.Context) error {
+	client, conn, err := connectForCommand(c)
 	if err != nil {
 		return fmt.Errorf("delete failed: %w", err)
 	}
 
-	ctx, cancel := context.WithTimeout(context.Background(), timeoutDuration)
+	ctx, cancel := context.WithTimeout(authContext(context.Background(), conn), timeoutDuration)
 	defer cancel()
 
-	streamName := c.String(streamFlag.Name)
-	subjectName := c.String(subjectFlag.Name)
+	return deleteStream(ctx, client, c)
+}
+
+// deleteStream is named to avoid shadowing the delete builtin.
+func deleteStream(ctx context.Context, client lift.Client, params Params) error {
+	streamName := params.String(streamFlag.Name)
+	subjectName := params.String(subjectFlag.Name)
 
-	if c.Bool(createStreamFlag.Name) {
+	if params.Bool(createStreamFlag.Name) {
 		if err := ensureStreamCreated(ctx, client, streamName, subjectName); err != nil {
 			return err
 		}
 	}
 
-	err = client.DeleteStream(
+	err := client.DeleteStream(
 		ctx,
 		streamName,
 	)
@@ -504,138 +1452,155 @@ func delete(c *cli.Context) error {
 	return nil
 }
 
-func brokerString(b *lift.BrokerInfo) string {
-	return fmt.Sprintf("%v (%v)", b.ID(), b.Addr())
+func brokerToRecord(b *lift.BrokerInfo) output.BrokerRecord {
+	return output.BrokerRecord{ID: b.ID(), Addr: b.Addr()}
+}
+
+func brokersToRecords(brokers []*lift.BrokerInfo) []output.BrokerRecord {
+	records := make([]output.BrokerRecord, 0, len(brokers))
+	for _, b := range brokers {
+		records = append(records, brokerToRecord(b))
+	}
+	return records
 }
 
-func metadata(c *cli.Context) error {
-	client, err := connectToEndpoint(c.String(addressFlag.Name))
+func metadataAction(c *cli.Context) error {
+	client, conn, err := connectForCommand(c)
 	if err != nil {
 		return fmt.Errorf("metadata fetching failed: %w", err)
 	}
 
-	ctx, cancel := context.WithTimeout(context.Background(), timeoutDuration)
+	ctx, cancel := context.WithTimeout(authContext(context.Background(), conn), timeoutDuration)
 	defer cancel()
 
+	return metadata(ctx, client, c)
+}
+
+func metadata(ctx context.Context, client lift.Client, params Params) error {
 	metadata, err := client.FetchMetadata(ctx)
 	if err != nil {
 		return fmt.Errorf("metadata fetching failed: %w", err)
 	}
 
-	// TODO: allow other output formats.
-	fmt.Printf("addresses:\n")
-	for _, addr := range metadata.Addrs() {
-		fmt.Printf(" %v\n", addr)
-	}
-	fmt.Printf("brokers:\n")
-	for _, broker := range metadata.Brokers() {
-		fmt.Printf(" %v\n", brokerString(broker))
-	}
-	fmt.Printf("last updated:\n %v\n", metadata.LastUpdated())
-
-	fmt.Printf("streams:\n")
+	streams := make([]output.StreamRecord, 0, len(metadata.Streams()))
 	for _, sv := range metadata.Streams() {
-		fmt.Printf(" %v (subject: %v)\n", sv.Name(), sv.Subject())
-		fmt.Printf("  partitions:\n")
+		partitions := make([]output.PartitionRecord, 0, len(sv.Partitions()))
 		for _, pv := range sv.Partitions() {
-			fmt.Printf("   %v \n", pv.ID())
-			fmt.Printf("    leader:\n     %v\n", brokerString(pv.Leader()))
-			fmt.Printf("    ISRs:\n")
-			for _, isr := range pv.ISR() {
-				fmt.Printf("     %v\n", brokerString(isr))
-			}
-			fmt.Printf("    replicas:\n")
-			for _, isr := range pv.Replicas() {
-				fmt.Printf("     %v\n", brokerString(isr))
-			}
+			partitions = append(partitions, output.PartitionRecord{
+				ID:       pv.ID(),
+				Leader:   brokerToRecord(pv.Leader()),
+				ISR:      brokersToRecords(pv.ISR()),
+				Replicas: brokersToRecords(pv.Replicas()),
+			})
 		}
-	}
-
-	return nil
-}
 
-func timeToString(time time.Time) string {
-	if time.IsZero() {
-		return "never"
+		streams = append(streams, output.StreamRecord{
+			Name:       sv.Name(),
+			Subject:    sv.Subject(),
+			Partitions: partitions,
+		})
 	}
 
-	return time.String()
-}
+	writer, err := newOutputWriter(params)
+	if err != nil {
+		return fmt.Errorf("metadata fetching failed: %w", err)
+	}
+	defer writer.Close()
 
-func partitionEventTimestampsToString(timestamp lift.PartitionEventTimestamps) string {
-	return fmt.Sprintf("first: %v, latest: %v", timeToString(timestamp.FirstTime()), timeToString(timestamp.LatestTime()))
+	return writer.WriteRecord(output.MetadataRecord{
+		Addrs:       metadata.Addrs(),
+		Brokers:     brokersToRecords(metadata.Brokers()),
+		LastUpdated: metadata.LastUpdated(),
+		Streams:     streams,
+	})
 }
 
-func partitionMetadata(c *cli.Context) error {
-	client, err := connectToEndpoint(c.String(addressFlag.Name))
+func partitionMetadataAction(c *cli.Context) error {
+	client, conn, err := connectForCommand(c)
 	if err != nil {
 		return fmt.Errorf("partition metadata fetching failed: %w", err)
 	}
 
-	ctx, cancel := context.WithTimeout(context.Background(), timeoutDuration)
+	ctx, cancel := context.WithTimeout(authContext(context.Background(), conn), timeoutDuration)
 	defer cancel()
 
-	streamName := c.String(streamFlag.Name)
-	subjectName := c.String(subjectFlag.Name)
+	return partitionMetadata(ctx, client, c)
+}
+
+func partitionMetadata(ctx context.Context, client lift.Client, params Params) error {
+	streamName := params.String(streamFlag.Name)
+	subjectName := params.String(subjectFlag.Name)
 
-	if c.Bool(createStreamFlag.Name) {
+	if params.Bool(createStreamFlag.Name) {
 		if err := ensureStreamCreated(ctx, client, streamName, subjectName); err != nil {
 			return err
 		}
 	}
 
-	partition := c.Int(partitionFlag.Name)
+	partition := params.Int(partitionFlag.Name)
 
 	metadata, err := client.FetchPartitionMetadata(ctx, streamName, int32(partition))
 	if err != nil {
 		return fmt.Errorf("metadata fetching failed: %w", err)
 	}
 
-	// TODO: allow other output formats.
-	fmt.Printf("%v\n", metadata.ID())
-	fmt.Printf(" leader:\n %v\n", brokerString(metadata.Leader()))
-	fmt.Printf(" ISRs:\n")
-	for _, isr := range metadata.ISR() {
-		fmt.Printf("  %v\n", brokerString(isr))
-	}
-	fmt.Printf(" replicas:\n")
-	for _, isr := range metadata.Replicas() {
-		fmt.Printf("  %v\n", brokerString(isr))
+	writer, err := newOutputWriter(params)
+	if err != nil {
+		return fmt.Errorf("metadata fetching failed: %w", err)
 	}
-	fmt.Printf(" high watermark:\n %v\n", metadata.HighWatermark())
-	fmt.Printf(" newest offset:\n %v\n", metadata.NewestOffset())
-	fmt.Printf(" paused:\n %v\n", metadata.Paused())
-	fmt.Printf(" read-only:\n %v\n", metadata.Readonly())
-	fmt.Printf(" message received timestamps:\n %v\n", partitionEventTimestampsToString(metadata.MessagesReceivedTimestamps()))
-	fmt.Printf(" pause timestamps:\n %v\n", partitionEventTimestampsToString(metadata.PauseTimestamps()))
-	fmt.Printf(" read-only timestamps:\n %v\n", partitionEventTimestampsToString(metadata.ReadonlyTimestamps()))
-
-	return nil
+	defer writer.Close()
+
+	return writer.WriteRecord(output.PartitionMetadataRecord{
+		ID:            metadata.ID(),
+		Leader:        brokerToRecord(metadata.Leader()),
+		ISR:           brokersToRecords(metadata.ISR()),
+		Replicas:      brokersToRecords(metadata.Replicas()),
+		HighWatermark: metadata.HighWatermark(),
+		NewestOffset:  metadata.NewestOffset(),
+		Paused:        metadata.Paused(),
+		Readonly:      metadata.Readonly(),
+		MessagesReceivedTimestamps: output.PartitionTimestamps{
+			First:  metadata.MessagesReceivedTimestamps().FirstTime(),
+			Latest: metadata.MessagesReceivedTimestamps().LatestTime(),
+		},
+		PauseTimestamps: output.PartitionTimestamps{
+			First:  metadata.PauseTimestamps().FirstTime(),
+			Latest: metadata.PauseTimestamps().LatestTime(),
+		},
+		ReadonlyTimestamps: output.PartitionTimestamps{
+			First:  metadata.ReadonlyTimestamps().FirstTime(),
+			Latest: metadata.ReadonlyTimestamps().LatestTime(),
+		},
+	})
 }
 
-func setCursor(c *cli.Context) error {
-	client, err := connectToEndpoint(c.String(addressFlag.Name))
+func setCursorAction(c *cli.Context) error {
+	client, conn, err := connectForCommand(c)
 	if err != nil {
 		return fmt.Errorf("setting cursor failed: %w", err)
 	}
 
-	ctx, cancel := context.WithTimeout(context.Background(), timeoutDuration)
+	ctx, cancel := context.WithTimeout(authContext(context.Background(), conn), timeoutDuration)
 	defer cancel()
 
-	streamName := c.String(streamFlag.Name)
-	subjectName := c.String(subjectFlag.Name)
+	return setCursor(ctx, client, c)
+}
+
+func setCursor(ctx context.Context, client lift.Client, params Params) error {
+	streamName := params.String(streamFlag.Name)
+	subjectName := params.String(subjectFlag.Name)
 
-	if c.Bool(createStreamFlag.Name) {
+	if params.Bool(createStreamFlag.Name) {
 		if err := ensureStreamCreated(ctx, client, streamName, subjectName); err != nil {
 			return err
 		}
 	}
 
-	cursorID := c.String(cursorIDFlag.Name)
-	partition := c.Int(partitionFlag.Name)
-	offset := c.Int64(offsetFlag.Name)
+	cursorID := params.String(cursorIDFlag.Name)
+	partition := params.Int(partitionFlag.Name)
+	offset := params.Int64(offsetFlag.Name)
 
-	err = client.SetCursor(ctx, cursorID, streamName, int32(partition), offset)
+	err := client.SetCursor(ctx, cursorID, streamName, int32(partition), offset)
 	if err != nil {
 		return fmt.Errorf("setting cursor failed: %w", err)
 	}
@@ -643,18 +1608,22 @@ func setCursor(c *cli.Context) error {
 	return nil
 }
 
-func fetchCursor(c *cli.Context) error {
-	client, err := connectToEndpoint(c.String(addressFlag.Name))
+func fetchCursorAction(c *cli.Context) error {
+	client, conn, err := connectForCommand(c)
 	if err != nil {
 		return fmt.Errorf("fetching cursor failed: %w", err)
 	}
 
-	ctx, cancel := context.WithTimeout(context.Background(), timeoutDuration)
+	ctx, cancel := context.WithTimeout(authContext(context.Background(), conn), timeoutDuration)
 	defer cancel()
 
-	streamName := c.String(streamFlag.Name)
-	cursorID := c.String(cursorIDFlag.Name)
-	partition := c.Int(partitionFlag.Name)
+	return fetchCursor(ctx, client, c)
+}
+
+func fetchCursor(ctx context.Context, client lift.Client, params Params) error {
+	streamName := params.String(streamFlag.Name)
+	cursorID := params.String(cursorIDFlag.Name)
+	partition := params.Int(partitionFlag.Name)
 
 	offset, err := client.FetchCursor(ctx, cursorID, streamName, int32(partition))
 	if err != nil {
@@ -673,11 +1642,27 @@ func Run(args []string) error {
 		Usage: "allows making requests to a Liftbridge server",
 		Flags: []cli.Flag{
 			addressFlag,
+			contextFlag,
+			tlsFlag,
+			tlsCAFlag,
+			tlsCertFlag,
+			tlsKeyFlag,
+			tlsServerNameFlag,
+			tlsInsecureSkipVerifyFlag,
+			authTokenFlag,
+			authTokenFileFlag,
+			outputFlag,
+			sinkFlag,
+			maxSizeFlag,
+			maxBackupsFlag,
+			maxAgeFlag,
 		},
 		Commands: []*cli.Command{
 			createCommand,
 			subscribeCommand,
 			subscribeActivityStreamCommand,
+			tailCommand,
+			daemonCommand,
 			publishCommand,
 			setReadonlyCommand,
 			pauseCommand,
@@ -686,6 +1671,7 @@ func Run(args []string) error {
 			partitionMetadataCommand,
 			setCursorCommand,
 			fetchCursorCommand,
+			shellCommand,
 		},
 	}
 
@@ -0,0 +1,83 @@
+package cli
+
+import (
+	"flag"
+	"testing"
+
+	"github.com/urfave/cli/v2"
+)
+
+func TestSplitAddresses(t *testing.T) {
+	cases := []struct {
+		raw  string
+		want []string
+	}{
+		{"127.0.0.1:9292", []string{"127.0.0.1:9292"}},
+		{"10.0.0.1:9292,10.0.0.2:9292", []string{"10.0.0.1:9292", "10.0.0.2:9292"}},
+		{"10.0.0.1:9292, 10.0.0.2:9292", []string{"10.0.0.1:9292", "10.0.0.2:9292"}},
+		{" 10.0.0.1:9292 , 10.0.0.2:9292 ", []string{"10.0.0.1:9292", "10.0.0.2:9292"}},
+		{"10.0.0.1:9292,,10.0.0.2:9292", []string{"10.0.0.1:9292", "10.0.0.2:9292"}},
+		{"", nil},
+	}
+
+	for _, c := range cases {
+		got := splitAddresses(c.raw)
+		if len(got) != len(c.want) {
+			t.Errorf("splitAddresses(%q) = %q, want %q", c.raw, got, c.want)
+			continue
+		}
+		for i := range got {
+			if got[i] != c.want[i] {
+				t.Errorf("splitAddresses(%q) = %q, want %q", c.raw, got, c.want)
+				break
+			}
+		}
+	}
+}
+
+// newTestContext builds a *cli.Context with the address, context, tls and
+// auth-token flags that resolveConnectionParams reads applied, parsing args
+// against them.
+func newTestContext(t *testing.T, args ...string) *cli.Context {
+	t.Helper()
+
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	for _, f := range []cli.Flag{addressFlag, contextFlag, tlsFlag, authTokenFlag, authTokenFileFlag} {
+		if err := f.Apply(fs); err != nil {
+			t.Fatalf("applying %v: %v", f.Names()[0], err)
+		}
+	}
+	if err := fs.Parse(args); err != nil {
+		t.Fatalf("parsing %q: %v", args, err)
+	}
+
+	return cli.NewContext(nil, fs, nil)
+}
+
+func TestResolveConnectionParamsTrimsMultipleAddresses(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir()) // no config file, so no context to merge in
+
+	conn, err := resolveConnectionParams(newTestContext(t, "--address", "10.0.0.1:9292, 10.0.0.2:9292"))
+	if err != nil {
+		t.Fatalf("resolveConnectionParams: %v", err)
+	}
+
+	want := []string{"10.0.0.1:9292", "10.0.0.2:9292"}
+	if len(conn.addresses) != len(want) {
+		t.Fatalf("conn.addresses = %q, want %q", conn.addresses, want)
+	}
+	for i := range want {
+		if conn.addresses[i] != want[i] {
+			t.Fatalf("conn.addresses = %q, want %q", conn.addresses, want)
+		}
+	}
+}
+
+func TestResolveConnectionParamsErrorsOnUnknownContext(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+
+	_, err := resolveConnectionParams(newTestContext(t, "--context", "does-not-exist"))
+	if err == nil {
+		t.Fatal("resolveConnectionParams with an unknown --context did not return an error")
+	}
+}
@@ -0,0 +1,19 @@
+package cli
+
+import "time"
+
+// Params is the subset of *cli.Context's flag accessors that action
+// functions need. Decoupling from *cli.Context lets the same action code
+// run from the one-shot CLI (where Params is satisfied directly by
+// *cli.Context) and from the interactive shell (where Params is satisfied
+// by a *cli.Context built from a parsed shell line, see shell.go).
+type Params interface {
+	String(name string) string
+	Bool(name string) bool
+	Int(name string) int
+	Int64(name string) int64
+	Float64(name string) float64
+	Duration(name string) time.Duration
+	IntSlice(name string) []int
+	IsSet(name string) bool
+}
@@ -0,0 +1,556 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/chzyer/readline"
+	lift "github.com/liftbridge-io/go-liftbridge/v2"
+	"github.com/urfave/cli/v2"
+)
+
+// shellOutputFlags are declared only on the top-level app, not on individual
+// Command.Flags (see Run), so parseShellFlags has to splice them into every
+// mirrored command's per-line flag set itself in order for `metadata
+// --output json` and friends to work inside the shell.
+var shellOutputFlags = []cli.Flag{outputFlag, sinkFlag, maxSizeFlag, maxBackupsFlag, maxAgeFlag}
+
+// shellSignals forwards SIGINT/SIGTERM to whichever foreground shell
+// command is currently running, using a single os/signal registration for
+// the life of the shell. signal.NotifyContext (as used by the top-level
+// signalContext) registers its own independent listener per call, and the
+// os/signal package fans a single incoming signal out to every registered
+// listener — so calling it once per shell command, as shellAction used to,
+// meant one Ctrl-C canceled every command with a live signalContext() at
+// once, including an unrelated background `subscribe`. Background
+// subscriptions deliberately don't register here at all (see
+// shellSubscribe), so they're unaffected by Ctrl-C and only stop via the
+// explicit `stop` command or shell exit.
+type shellSignals struct {
+	mu     sync.Mutex
+	nextID int
+	active map[int]context.CancelFunc
+}
+
+// newShellSignals starts listening for SIGINT/SIGTERM and returns a stop
+// func that must be called to release the signal registration.
+func newShellSignals() (*shellSignals, func()) {
+	s := &shellSignals{active: make(map[int]context.CancelFunc)}
+
+	sigC := make(chan os.Signal, 1)
+	signal.Notify(sigC, os.Interrupt, syscall.SIGTERM)
+
+	done := make(chan struct{})
+	go func() {
+		for {
+			select {
+			case <-sigC:
+				s.cancelActive()
+			case <-done:
+				return
+			}
+		}
+	}()
+
+	return s, func() {
+		signal.Stop(sigC)
+		close(done)
+	}
+}
+
+// context returns a context derived from parent that is canceled when a
+// signal arrives while it is registered, and a cancel func that must be
+// called (e.g. via defer) once the command finishes to unregister it.
+func (s *shellSignals) context(parent context.Context) (context.Context, context.CancelFunc) {
+	ctx, cancel := context.WithCancel(parent)
+
+	s.mu.Lock()
+	id := s.nextID
+	s.nextID++
+	s.active[id] = cancel
+	s.mu.Unlock()
+
+	return ctx, func() {
+		cancel()
+		s.mu.Lock()
+		delete(s.active, id)
+		s.mu.Unlock()
+	}
+}
+
+func (s *shellSignals) cancelActive() {
+	s.mu.Lock()
+	cancels := make([]context.CancelFunc, 0, len(s.active))
+	for _, cancel := range s.active {
+		cancels = append(cancels, cancel)
+	}
+	s.mu.Unlock()
+
+	for _, cancel := range cancels {
+		cancel()
+	}
+}
+
+var shellCommand = &cli.Command{
+	Name:    "shell",
+	Aliases: []string{"sh"},
+	Usage:   "Starts an interactive shell backed by a single persistent connection",
+	Action:  shellAction,
+}
+
+// errShellQuit unwinds the REPL loop in shellAction.
+var errShellQuit = errors.New("quit")
+
+// shellState holds the REPL's working defaults, set by the shell-only `use`
+// helper so mirrored commands don't need an explicit --stream every time, and
+// tracks the background `subscribe` started by shellSubscribe, if any, so
+// `stats` can report its live throughput and `stop` can cancel it.
+type shellState struct {
+	stream string
+
+	mu         sync.Mutex
+	generation int
+	cancel     context.CancelFunc
+	stats      *subscriptionStats
+}
+
+// startSubscription cancels any previously running background subscription,
+// registers cancel and stats in its place, and returns a generation token
+// that endSubscription must present to clear them again. The token guards
+// against a just-superseded subscription's own cleanup wiping out the one
+// that replaced it.
+func (s *shellState) startSubscription(cancel context.CancelFunc, stats *subscriptionStats) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.cancel != nil {
+		s.cancel()
+	}
+	s.generation++
+	s.cancel = cancel
+	s.stats = stats
+	return s.generation
+}
+
+// endSubscription clears the tracked subscription, but only if generation is
+// still the current one.
+func (s *shellState) endSubscription(generation int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.generation == generation {
+		s.cancel = nil
+		s.stats = nil
+	}
+}
+
+// stopActive cancels the running background subscription, if any, and
+// reports whether one was actually running.
+func (s *shellState) stopActive() bool {
+	s.mu.Lock()
+	cancel := s.cancel
+	s.mu.Unlock()
+	if cancel == nil {
+		return false
+	}
+	cancel()
+	return true
+}
+
+func (s *shellState) activeStats() *subscriptionStats {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.stats
+}
+
+// subscriptionStats tracks message throughput for the shell's currently
+// running background subscription, so `stats` can report a live number
+// instead of only generic cluster metadata.
+type subscriptionStats struct {
+	stream    string
+	startedAt time.Time
+
+	mu    sync.Mutex
+	count int64
+}
+
+func newSubscriptionStats(stream string) *subscriptionStats {
+	return &subscriptionStats{stream: stream, startedAt: time.Now()}
+}
+
+func (s *subscriptionStats) record() {
+	s.mu.Lock()
+	s.count++
+	s.mu.Unlock()
+}
+
+// snapshot returns the message count seen so far and the average throughput
+// since the subscription started.
+func (s *subscriptionStats) snapshot() (count int64, perSecond float64) {
+	s.mu.Lock()
+	count = s.count
+	s.mu.Unlock()
+
+	elapsed := time.Since(s.startedAt).Seconds()
+	if elapsed <= 0 {
+		elapsed = 1
+	}
+	return count, float64(count) / elapsed
+}
+
+// shellCommandDef maps a mirrored shell command name to the *cli.Command it
+// borrows flag definitions from and the core action function it invokes.
+// publish, the only remaining longRunning entry, gets a signal-canceled
+// context with no deadline, like its top-level equivalent; everything else
+// gets the same timeoutDuration-bounded context the one-shot CLI actions
+// use. subscribe is handled separately by shellSubscribe: unlike these, it
+// must not block the REPL loop, since that would make `stats` impossible to
+// type while it's running.
+type shellCommandDef struct {
+	command     *cli.Command
+	longRunning bool
+	run         func(ctx context.Context, client lift.Client, conn connectionParams, params Params) error
+}
+
+var shellCommands = map[string]shellCommandDef{
+	"create": {createCommand, false, func(ctx context.Context, client lift.Client, _ connectionParams, params Params) error {
+		return create(ctx, client, params)
+	}},
+	"publish": {publishCommand, true, func(ctx context.Context, client lift.Client, _ connectionParams, params Params) error {
+		ctx, cancel := context.WithCancel(ctx)
+		defer cancel()
+		return publish(ctx, cancel, client, params)
+	}},
+	"metadata": {metadataCommand, false, func(ctx context.Context, client lift.Client, _ connectionParams, params Params) error {
+		return metadata(ctx, client, params)
+	}},
+	"partition-metadata": {partitionMetadataCommand, false, func(ctx context.Context, client lift.Client, _ connectionParams, params Params) error {
+		return partitionMetadata(ctx, client, params)
+	}},
+	"set-cursor": {setCursorCommand, false, func(ctx context.Context, client lift.Client, _ connectionParams, params Params) error {
+		return setCursor(ctx, client, params)
+	}},
+	"fetch-cursor": {fetchCursorCommand, false, func(ctx context.Context, client lift.Client, _ connectionParams, params Params) error {
+		return fetchCursor(ctx, client, params)
+	}},
+	"set-readonly": {setReadonlyCommand, false, func(ctx context.Context, client lift.Client, _ connectionParams, params Params) error {
+		return setReadonly(ctx, client, params)
+	}},
+	"pause": {pauseCommand, false, func(ctx context.Context, client lift.Client, _ connectionParams, params Params) error {
+		return pause(ctx, client, params)
+	}},
+	"delete": {deleteCommand, false, func(ctx context.Context, client lift.Client, _ connectionParams, params Params) error {
+		return deleteStream(ctx, client, params)
+	}},
+}
+
+func shellAction(c *cli.Context) error {
+	conn, err := resolveConnectionParams(c)
+	if err != nil {
+		return fmt.Errorf("shell failed: %w", err)
+	}
+
+	client, err := connectToEndpoint(conn)
+	if err != nil {
+		return fmt.Errorf("shell failed: %w", err)
+	}
+	defer client.Close()
+
+	rl, err := readline.New("")
+	if err != nil {
+		return fmt.Errorf("shell failed: %w", err)
+	}
+	defer rl.Close()
+
+	state := &shellState{stream: defaultStreamName}
+	defer state.stopActive()
+
+	signals, stopSignals := newShellSignals()
+	defer stopSignals()
+
+	for {
+		rl.SetPrompt(fmt.Sprintf("liftbridge(%s)> ", state.stream))
+
+		line, err := rl.Readline()
+		if err != nil { // io.EOF (Ctrl-D) or readline.ErrInterrupt (Ctrl-C)
+			return nil
+		}
+
+		if line = strings.TrimSpace(line); line == "" {
+			continue
+		}
+
+		if err := dispatchShellLine(client, conn, state, c, signals, line); err != nil {
+			if errors.Is(err, errShellQuit) {
+				return nil
+			}
+			fmt.Printf("error: %v\n", err)
+		}
+	}
+}
+
+func dispatchShellLine(client lift.Client, conn connectionParams, state *shellState, parent *cli.Context, signals *shellSignals, line string) error {
+	args := splitShellArgs(line)
+	if len(args) == 0 {
+		return nil
+	}
+	name, rest := args[0], args[1:]
+
+	switch name {
+	case `\q`, "quit", "exit":
+		return errShellQuit
+	case "use":
+		if len(rest) != 1 {
+			return errors.New(`usage: use <stream>`)
+		}
+		state.stream = rest[0]
+		return nil
+	case "watch":
+		return shellWatchMetadata(client, conn, signals, rest)
+	case "stats":
+		return shellStats(client, conn, state)
+	case "subscribe":
+		return shellSubscribe(client, conn, state, parent, rest)
+	case "stop":
+		if state.stopActive() {
+			return nil
+		}
+		return errors.New("no active subscription to stop")
+	}
+
+	def, ok := shellCommands[name]
+	if !ok {
+		return fmt.Errorf("unknown command %q (try \\q, use, watch, stats, subscribe, stop, or: %s)", name, shellCommandNames())
+	}
+
+	params, err := parseShellFlags(def.command, state, parent, rest)
+	if err != nil {
+		return err
+	}
+
+	if def.longRunning {
+		ctx, cancel := signals.context(context.Background())
+		defer cancel()
+		return def.run(authContext(ctx, conn), client, conn, params)
+	}
+
+	ctx, cancel := context.WithTimeout(authContext(context.Background(), conn), timeoutDuration)
+	defer cancel()
+	return def.run(ctx, client, conn, params)
+}
+
+func shellCommandNames() string {
+	names := make([]string, 0, len(shellCommands))
+	for name := range shellCommands {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return strings.Join(names, ", ")
+}
+
+// parseShellFlags parses args against cmd's flags plus shellOutputFlags,
+// defaulting --stream to the shell's current `use`-selected stream when the
+// command takes one and the caller didn't pass it explicitly, and defaulting
+// --output/--sink/etc. to whatever was passed to `shell` itself so a line
+// can still override them (e.g. `metadata --output json`). The returned
+// context is parented to parent so any flag not handled above (or not
+// overridden on the line) still resolves through the app-level context.
+func parseShellFlags(cmd *cli.Command, state *shellState, parent *cli.Context, args []string) (*cli.Context, error) {
+	fs := flag.NewFlagSet(cmd.Name, flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+
+	var hasStreamFlag bool
+	for _, f := range cmd.Flags {
+		if err := f.Apply(fs); err != nil {
+			return nil, fmt.Errorf("%s: %w", cmd.Name, err)
+		}
+		if f.Names()[0] == streamFlag.Name {
+			hasStreamFlag = true
+		}
+	}
+
+	for _, f := range shellOutputFlags {
+		if err := f.Apply(fs); err != nil {
+			return nil, fmt.Errorf("%s: %w", cmd.Name, err)
+		}
+
+		name := f.Names()[0]
+		value := parent.String(name)
+		if _, isIntFlag := f.(*cli.IntFlag); isIntFlag {
+			value = strconv.Itoa(parent.Int(name))
+		}
+		if err := fs.Set(name, value); err != nil {
+			return nil, fmt.Errorf("%s: %w", cmd.Name, err)
+		}
+	}
+
+	if hasStreamFlag {
+		if err := fs.Set(streamFlag.Name, state.stream); err != nil {
+			return nil, fmt.Errorf("%s: %w", cmd.Name, err)
+		}
+	}
+
+	if err := fs.Parse(args); err != nil {
+		return nil, fmt.Errorf("%s: %w", cmd.Name, err)
+	}
+
+	return cli.NewContext(parent.App, fs, parent), nil
+}
+
+// splitShellArgs tokenizes a shell line on whitespace, treating single- or
+// double-quoted runs as one token so values like --message "hello world"
+// work.
+func splitShellArgs(line string) []string {
+	var args []string
+	var buf strings.Builder
+	var quote rune
+
+	for _, r := range line {
+		switch {
+		case quote != 0:
+			if r == quote {
+				quote = 0
+			} else {
+				buf.WriteRune(r)
+			}
+		case r == '"' || r == '\'':
+			quote = r
+		case r == ' ' || r == '\t':
+			if buf.Len() > 0 {
+				args = append(args, buf.String())
+				buf.Reset()
+			}
+		default:
+			buf.WriteRune(r)
+		}
+	}
+
+	if buf.Len() > 0 {
+		args = append(args, buf.String())
+	}
+
+	return args
+}
+
+// shellSubscribe starts `subscribe` in the background and returns
+// immediately, so the REPL stays responsive while it runs; `stats` reports
+// its live throughput and `stop` cancels it. It replaces any subscription
+// already running. Unlike the other shell commands, it deliberately does
+// not register with shellSignals: Ctrl-C is meant for whatever foreground
+// command the user is looking at, not for a background subscription that
+// outlives it, so this can only be stopped via `stop` or shell exit.
+func shellSubscribe(client lift.Client, conn connectionParams, state *shellState, parent *cli.Context, args []string) error {
+	params, err := parseShellFlags(subscribeCommand, state, parent, args)
+	if err != nil {
+		return err
+	}
+
+	writer, err := newOutputWriter(params)
+	if err != nil {
+		return fmt.Errorf("stream subscription failed: %w", err)
+	}
+
+	streamName := params.String(streamFlag.Name)
+	subjectName := params.String(subjectFlag.Name)
+
+	stats := newSubscriptionStats(streamName)
+	handler := messageHandler(writer, streamName)
+	countingHandler := func(m *lift.Message) {
+		stats.record()
+		handler(m)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	ctx = authContext(ctx, conn)
+	generation := state.startSubscription(cancel, stats)
+
+	go func() {
+		defer writer.Close()
+
+		err := subscribeToStream(ctx, streamName, subjectName, countingHandler,
+			conn, params.Bool(createStreamFlag.Name), params.String(cursorFileFlag.Name))
+		state.endSubscription(generation)
+		if err != nil && ctx.Err() == nil {
+			fmt.Printf("\nerror: %v\n", err)
+		}
+	}()
+
+	fmt.Printf("subscribed to %s in the background; use `stats` for throughput or `stop` to cancel\n", streamName)
+	return nil
+}
+
+// shellStats prints the live throughput of the shell's active background
+// subscription, if any, or else falls back to a one-line summary of the
+// shell's connection and the broker's current metadata.
+func shellStats(client lift.Client, conn connectionParams, state *shellState) error {
+	if stats := state.activeStats(); stats != nil {
+		count, perSecond := stats.snapshot()
+		fmt.Printf("subscription: %s, messages: %d, throughput: %.1f msg/s\n", stats.stream, count, perSecond)
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(authContext(context.Background(), conn), timeoutDuration)
+	defer cancel()
+
+	metadata, err := client.FetchMetadata(ctx)
+	if err != nil {
+		return fmt.Errorf("fetching stats failed: %w", err)
+	}
+
+	fmt.Printf("stream: %v, brokers: %v, streams: %v, last updated: %v\n",
+		state.stream, len(metadata.Brokers()), len(metadata.Streams()), metadata.LastUpdated())
+
+	return nil
+}
+
+// shellWatchMetadata re-fetches and prints a metadata summary every
+// interval (default 2s, overridable via `watch metadata <seconds>`) until
+// interrupted.
+func shellWatchMetadata(client lift.Client, conn connectionParams, signals *shellSignals, args []string) error {
+	if len(args) == 0 || args[0] != "metadata" {
+		return errors.New("usage: watch metadata [interval-seconds]")
+	}
+
+	interval := 2 * time.Second
+	if len(args) > 1 {
+		seconds, err := strconv.Atoi(args[1])
+		if err != nil || seconds <= 0 {
+			return fmt.Errorf("invalid interval %q", args[1])
+		}
+		interval = time.Duration(seconds) * time.Second
+	}
+
+	ctx, cancel := signals.context(context.Background())
+	defer cancel()
+	ctx = authContext(ctx, conn)
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		fetchCtx, fetchCancel := context.WithTimeout(ctx, timeoutDuration)
+		metadata, err := client.FetchMetadata(fetchCtx)
+		fetchCancel()
+		if err != nil {
+			return fmt.Errorf("watching metadata failed: %w", err)
+		}
+
+		fmt.Printf("brokers: %v, streams: %v, last updated: %v\n",
+			len(metadata.Brokers()), len(metadata.Streams()), metadata.LastUpdated())
+
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return nil
+		}
+	}
+}
@@ -0,0 +1,98 @@
+package cli
+
+import (
+	"flag"
+	"testing"
+
+	"github.com/urfave/cli/v2"
+)
+
+func TestSplitShellArgs(t *testing.T) {
+	cases := []struct {
+		line string
+		want []string
+	}{
+		{"", nil},
+		{"  ", nil},
+		{"metadata", []string{"metadata"}},
+		{"publish --message hello", []string{"publish", "--message", "hello"}},
+		{`publish --message "hello world"`, []string{"publish", "--message", "hello world"}},
+		{`publish --message 'hello world'`, []string{"publish", "--message", "hello world"}},
+		{`use  my-stream`, []string{"use", "my-stream"}},
+	}
+
+	for _, c := range cases {
+		got := splitShellArgs(c.line)
+		if len(got) != len(c.want) {
+			t.Errorf("splitShellArgs(%q) = %q, want %q", c.line, got, c.want)
+			continue
+		}
+		for i := range got {
+			if got[i] != c.want[i] {
+				t.Errorf("splitShellArgs(%q) = %q, want %q", c.line, got, c.want)
+				break
+			}
+		}
+	}
+}
+
+func newTestParentContext(t *testing.T, args ...string) *cli.Context {
+	t.Helper()
+
+	fs := flag.NewFlagSet("shell", flag.ContinueOnError)
+	for _, f := range shellOutputFlags {
+		if err := f.Apply(fs); err != nil {
+			t.Fatalf("applying %v: %v", f.Names()[0], err)
+		}
+	}
+	if err := fs.Parse(args); err != nil {
+		t.Fatalf("parsing %q: %v", args, err)
+	}
+
+	return cli.NewContext(nil, fs, nil)
+}
+
+func TestParseShellFlagsInheritsOutputFromParent(t *testing.T) {
+	parent := newTestParentContext(t, "--output", "json", "--sink", "file:/tmp/out")
+	state := &shellState{stream: defaultStreamName}
+
+	params, err := parseShellFlags(metadataCommand, state, parent, nil)
+	if err != nil {
+		t.Fatalf("parseShellFlags: %v", err)
+	}
+
+	if got := params.String(outputFlag.Name); got != "json" {
+		t.Errorf("params.String(output) = %q, want %q (inherited from parent)", got, "json")
+	}
+	if got := params.String(sinkFlag.Name); got != "file:/tmp/out" {
+		t.Errorf("params.String(sink) = %q, want %q (inherited from parent)", got, "file:/tmp/out")
+	}
+}
+
+func TestParseShellFlagsLineOverridesParent(t *testing.T) {
+	parent := newTestParentContext(t, "--output", "json")
+	state := &shellState{stream: defaultStreamName}
+
+	params, err := parseShellFlags(metadataCommand, state, parent, []string{"--output", "yaml"})
+	if err != nil {
+		t.Fatalf("parseShellFlags: %v", err)
+	}
+
+	if got := params.String(outputFlag.Name); got != "yaml" {
+		t.Errorf("params.String(output) = %q, want %q (overridden on the line)", got, "yaml")
+	}
+}
+
+func TestParseShellFlagsDefaultsStreamFromUse(t *testing.T) {
+	parent := newTestParentContext(t)
+	state := &shellState{stream: "orders"}
+
+	params, err := parseShellFlags(subscribeCommand, state, parent, nil)
+	if err != nil {
+		t.Fatalf("parseShellFlags: %v", err)
+	}
+
+	if got := params.String(streamFlag.Name); got != "orders" {
+		t.Errorf("params.String(stream) = %q, want %q (defaulted from `use`)", got, "orders")
+	}
+}
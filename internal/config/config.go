@@ -0,0 +1,89 @@
+// Package config loads the CLI's optional config file, which holds
+// kubectl-style named connection profiles ("contexts") so users don't
+// have to repeat --address/--tls-*/--auth-token flags on every
+// invocation.
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Config is the parsed contents of the config file.
+type Config struct {
+	CurrentContext string             `yaml:"current-context"`
+	Contexts       map[string]Context `yaml:"contexts"`
+}
+
+// Context is a single named connection profile.
+type Context struct {
+	Addresses     []string `yaml:"addresses"`
+	TLS           *TLS     `yaml:"tls"`
+	AuthToken     string   `yaml:"auth-token"`
+	AuthTokenFile string   `yaml:"auth-token-file"`
+}
+
+// TLS is a context's TLS/mTLS settings.
+type TLS struct {
+	Enabled            bool   `yaml:"enabled"`
+	CA                 string `yaml:"ca"`
+	Cert               string `yaml:"cert"`
+	Key                string `yaml:"key"`
+	ServerName         string `yaml:"server-name"`
+	InsecureSkipVerify bool   `yaml:"insecure-skip-verify"`
+}
+
+// Load reads the config file at $XDG_CONFIG_HOME/liftbridge-cli/config.yaml
+// (falling back to ~/.config when XDG_CONFIG_HOME is unset). A missing file
+// is not an error; it yields a zero-value Config.
+func Load() (*Config, error) {
+	path, err := configFilePath()
+	if err != nil || path == "" {
+		return &Config{}, err
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return &Config{}, nil
+		}
+		return nil, fmt.Errorf("reading config file %v: %w", path, err)
+	}
+
+	var cfg Config
+	if err := yaml.Unmarshal(data, &cfg); err != nil {
+		return nil, fmt.Errorf("parsing config file %v: %w", path, err)
+	}
+
+	return &cfg, nil
+}
+
+// Context looks up a named context, falling back to CurrentContext when
+// name is empty. It reports false if no such context is configured.
+func (c *Config) Context(name string) (Context, bool) {
+	if name == "" {
+		name = c.CurrentContext
+	}
+	if name == "" {
+		return Context{}, false
+	}
+
+	ctx, ok := c.Contexts[name]
+	return ctx, ok
+}
+
+func configFilePath() (string, error) {
+	dir := os.Getenv("XDG_CONFIG_HOME")
+	if dir == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return "", fmt.Errorf("locating home directory: %w", err)
+		}
+		dir = filepath.Join(home, ".config")
+	}
+
+	return filepath.Join(dir, "liftbridge-cli", "config.yaml"), nil
+}
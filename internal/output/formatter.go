@@ -0,0 +1,109 @@
+package output
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"text/template"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Formatter renders a Record to bytes in a particular output format.
+type Formatter interface {
+	Format(Record) ([]byte, error)
+}
+
+// TextFormatter renders a Record the way the CLI has always printed it.
+type TextFormatter struct{}
+
+func (TextFormatter) Format(r Record) ([]byte, error) {
+	return []byte(r.Text() + "\n"), nil
+}
+
+// JSONFormatter renders a Record as indented JSON, suitable for one-off
+// commands such as metadata.
+type JSONFormatter struct{}
+
+func (JSONFormatter) Format(r Record) ([]byte, error) {
+	data, err := json.MarshalIndent(r, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("formatting record as json: %w", err)
+	}
+
+	return append(data, '\n'), nil
+}
+
+// JSONLFormatter renders a Record as a single line of JSON, suitable for
+// streaming commands such as subscribe or tail.
+type JSONLFormatter struct{}
+
+func (JSONLFormatter) Format(r Record) ([]byte, error) {
+	data, err := json.Marshal(r)
+	if err != nil {
+		return nil, fmt.Errorf("formatting record as jsonl: %w", err)
+	}
+
+	return append(data, '\n'), nil
+}
+
+// YAMLFormatter renders a Record as a YAML document.
+type YAMLFormatter struct{}
+
+func (YAMLFormatter) Format(r Record) ([]byte, error) {
+	data, err := yaml.Marshal(r)
+	if err != nil {
+		return nil, fmt.Errorf("formatting record as yaml: %w", err)
+	}
+
+	return append(data, []byte("---\n")...), nil
+}
+
+// TemplateFormatter renders a Record using a user-supplied Go template.
+type TemplateFormatter struct {
+	tmpl *template.Template
+}
+
+// NewTemplateFormatter parses text as a Go template to be executed
+// against each Record.
+func NewTemplateFormatter(text string) (*TemplateFormatter, error) {
+	tmpl, err := template.New("output").Parse(text)
+	if err != nil {
+		return nil, fmt.Errorf("invalid output template: %w", err)
+	}
+
+	return &TemplateFormatter{tmpl: tmpl}, nil
+}
+
+func (f *TemplateFormatter) Format(r Record) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := f.tmpl.Execute(&buf, r); err != nil {
+		return nil, fmt.Errorf("executing output template: %w", err)
+	}
+
+	buf.WriteByte('\n')
+
+	return buf.Bytes(), nil
+}
+
+// NewFormatter builds a Formatter from an --output flag value: "text",
+// "json", "jsonl", "yaml", or "template=<go-template>".
+func NewFormatter(spec string) (Formatter, error) {
+	if rest, ok := strings.CutPrefix(spec, "template="); ok {
+		return NewTemplateFormatter(rest)
+	}
+
+	switch spec {
+	case "", "text":
+		return TextFormatter{}, nil
+	case "json":
+		return JSONFormatter{}, nil
+	case "jsonl":
+		return JSONLFormatter{}, nil
+	case "yaml":
+		return YAMLFormatter{}, nil
+	default:
+		return nil, fmt.Errorf("invalid output format: %v", spec)
+	}
+}
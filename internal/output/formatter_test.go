@@ -0,0 +1,89 @@
+package output
+
+import (
+	"strings"
+	"testing"
+)
+
+type fakeRecord struct {
+	Name string `json:"name" yaml:"name"`
+}
+
+func (r fakeRecord) Text() string { return "name: " + r.Name }
+
+func TestNewFormatter(t *testing.T) {
+	cases := []struct {
+		spec string
+		want Formatter
+	}{
+		{"", TextFormatter{}},
+		{"text", TextFormatter{}},
+		{"json", JSONFormatter{}},
+		{"jsonl", JSONLFormatter{}},
+		{"yaml", YAMLFormatter{}},
+	}
+
+	for _, c := range cases {
+		got, err := NewFormatter(c.spec)
+		if err != nil {
+			t.Errorf("NewFormatter(%q) returned error: %v", c.spec, err)
+			continue
+		}
+		if got != c.want {
+			t.Errorf("NewFormatter(%q) = %#v, want %#v", c.spec, got, c.want)
+		}
+	}
+
+	if _, err := NewFormatter("bogus"); err == nil {
+		t.Error("NewFormatter(\"bogus\") did not return an error")
+	}
+}
+
+func TestTextFormatter(t *testing.T) {
+	data, err := TextFormatter{}.Format(fakeRecord{Name: "orders"})
+	if err != nil {
+		t.Fatalf("Format: %v", err)
+	}
+	if string(data) != "name: orders\n" {
+		t.Errorf("Format() = %q, want %q", data, "name: orders\n")
+	}
+}
+
+func TestJSONFormatter(t *testing.T) {
+	data, err := JSONFormatter{}.Format(fakeRecord{Name: "orders"})
+	if err != nil {
+		t.Fatalf("Format: %v", err)
+	}
+	if !strings.Contains(string(data), `"name": "orders"`) {
+		t.Errorf("Format() = %q, want it to contain the name field", data)
+	}
+}
+
+func TestJSONLFormatterIsSingleLine(t *testing.T) {
+	data, err := JSONLFormatter{}.Format(fakeRecord{Name: "orders"})
+	if err != nil {
+		t.Fatalf("Format: %v", err)
+	}
+	if strings.Count(string(data), "\n") != 1 {
+		t.Errorf("Format() = %q, want exactly one trailing newline", data)
+	}
+}
+
+func TestTemplateFormatter(t *testing.T) {
+	f, err := NewTemplateFormatter("stream={{.Name}}")
+	if err != nil {
+		t.Fatalf("NewTemplateFormatter: %v", err)
+	}
+
+	data, err := f.Format(fakeRecord{Name: "orders"})
+	if err != nil {
+		t.Fatalf("Format: %v", err)
+	}
+	if string(data) != "stream=orders\n" {
+		t.Errorf("Format() = %q, want %q", data, "stream=orders\n")
+	}
+
+	if _, err := NewTemplateFormatter("{{"); err == nil {
+		t.Error("NewTemplateFormatter with invalid template did not return an error")
+	}
+}
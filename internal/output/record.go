@@ -0,0 +1,175 @@
+package output
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Record is implemented by every structured record the CLI can emit. Text
+// renders the record the same way the CLI has always printed it, and is
+// used by the "text" formatter; the json/yaml/template formatters render
+// the record's exported fields directly.
+type Record interface {
+	Text() string
+}
+
+// BrokerRecord is the structured form of a lift.BrokerInfo.
+type BrokerRecord struct {
+	ID   string `json:"id" yaml:"id"`
+	Addr string `json:"addr" yaml:"addr"`
+}
+
+func (b BrokerRecord) String() string {
+	return fmt.Sprintf("%v (%v)", b.ID, b.Addr)
+}
+
+// PartitionTimestamps is the structured form of a
+// lift.PartitionEventTimestamps.
+type PartitionTimestamps struct {
+	First  time.Time `json:"first" yaml:"first"`
+	Latest time.Time `json:"latest" yaml:"latest"`
+}
+
+func (t PartitionTimestamps) String() string {
+	return fmt.Sprintf("first: %v, latest: %v", timeString(t.First), timeString(t.Latest))
+}
+
+func timeString(t time.Time) string {
+	if t.IsZero() {
+		return "never"
+	}
+
+	return t.String()
+}
+
+// PartitionRecord is the structured form of a stream partition as
+// returned by FetchMetadata.
+type PartitionRecord struct {
+	ID       int32          `json:"id" yaml:"id"`
+	Leader   BrokerRecord   `json:"leader" yaml:"leader"`
+	ISR      []BrokerRecord `json:"isr" yaml:"isr"`
+	Replicas []BrokerRecord `json:"replicas" yaml:"replicas"`
+}
+
+// StreamRecord is the structured form of a stream as returned by
+// FetchMetadata.
+type StreamRecord struct {
+	Name       string            `json:"name" yaml:"name"`
+	Subject    string            `json:"subject" yaml:"subject"`
+	Partitions []PartitionRecord `json:"partitions" yaml:"partitions"`
+}
+
+// MetadataRecord is the structured form of the metadata command's output.
+type MetadataRecord struct {
+	Addrs       []string       `json:"addrs" yaml:"addrs"`
+	Brokers     []BrokerRecord `json:"brokers" yaml:"brokers"`
+	LastUpdated time.Time      `json:"lastUpdated" yaml:"lastUpdated"`
+	Streams     []StreamRecord `json:"streams" yaml:"streams"`
+}
+
+// Text renders the record the way the metadata command has always
+// printed it.
+func (r MetadataRecord) Text() string {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "addresses:\n")
+	for _, addr := range r.Addrs {
+		fmt.Fprintf(&b, " %v\n", addr)
+	}
+
+	fmt.Fprintf(&b, "brokers:\n")
+	for _, broker := range r.Brokers {
+		fmt.Fprintf(&b, " %v\n", broker)
+	}
+
+	fmt.Fprintf(&b, "last updated:\n %v\n", r.LastUpdated)
+
+	fmt.Fprintf(&b, "streams:\n")
+	for _, sv := range r.Streams {
+		fmt.Fprintf(&b, " %v (subject: %v)\n", sv.Name, sv.Subject)
+		fmt.Fprintf(&b, "  partitions:\n")
+		for _, pv := range sv.Partitions {
+			fmt.Fprintf(&b, "   %v \n", pv.ID)
+			fmt.Fprintf(&b, "    leader:\n     %v\n", pv.Leader)
+			fmt.Fprintf(&b, "    ISRs:\n")
+			for _, isr := range pv.ISR {
+				fmt.Fprintf(&b, "     %v\n", isr)
+			}
+			fmt.Fprintf(&b, "    replicas:\n")
+			for _, replica := range pv.Replicas {
+				fmt.Fprintf(&b, "     %v\n", replica)
+			}
+		}
+	}
+
+	return strings.TrimSuffix(b.String(), "\n")
+}
+
+// PartitionMetadataRecord is the structured form of the
+// partition-metadata command's output.
+type PartitionMetadataRecord struct {
+	ID                         int32               `json:"id" yaml:"id"`
+	Leader                     BrokerRecord        `json:"leader" yaml:"leader"`
+	ISR                        []BrokerRecord      `json:"isr" yaml:"isr"`
+	Replicas                   []BrokerRecord      `json:"replicas" yaml:"replicas"`
+	HighWatermark              int64               `json:"highWatermark" yaml:"highWatermark"`
+	NewestOffset               int64               `json:"newestOffset" yaml:"newestOffset"`
+	Paused                     bool                `json:"paused" yaml:"paused"`
+	Readonly                   bool                `json:"readonly" yaml:"readonly"`
+	MessagesReceivedTimestamps PartitionTimestamps `json:"messagesReceivedTimestamps" yaml:"messagesReceivedTimestamps"`
+	PauseTimestamps            PartitionTimestamps `json:"pauseTimestamps" yaml:"pauseTimestamps"`
+	ReadonlyTimestamps         PartitionTimestamps `json:"readonlyTimestamps" yaml:"readonlyTimestamps"`
+}
+
+// Text renders the record the way the partition-metadata command has
+// always printed it.
+func (r PartitionMetadataRecord) Text() string {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "%v\n", r.ID)
+	fmt.Fprintf(&b, " leader:\n %v\n", r.Leader)
+	fmt.Fprintf(&b, " ISRs:\n")
+	for _, isr := range r.ISR {
+		fmt.Fprintf(&b, "  %v\n", isr)
+	}
+	fmt.Fprintf(&b, " replicas:\n")
+	for _, replica := range r.Replicas {
+		fmt.Fprintf(&b, "  %v\n", replica)
+	}
+	fmt.Fprintf(&b, " high watermark:\n %v\n", r.HighWatermark)
+	fmt.Fprintf(&b, " newest offset:\n %v\n", r.NewestOffset)
+	fmt.Fprintf(&b, " paused:\n %v\n", r.Paused)
+	fmt.Fprintf(&b, " read-only:\n %v\n", r.Readonly)
+	fmt.Fprintf(&b, " message received timestamps:\n %v\n", r.MessagesReceivedTimestamps)
+	fmt.Fprintf(&b, " pause timestamps:\n %v\n", r.PauseTimestamps)
+	fmt.Fprintf(&b, " read-only timestamps:\n %v", r.ReadonlyTimestamps)
+
+	return b.String()
+}
+
+// MessageRecord is the structured form of a message received via
+// subscribe, tail, or daemon.
+type MessageRecord struct {
+	Stream    string `json:"stream" yaml:"stream"`
+	Partition int32  `json:"partition" yaml:"partition"`
+	Offset    int64  `json:"offset" yaml:"offset"`
+	Key       []byte `json:"key,omitempty" yaml:"key,omitempty"`
+	Value     []byte `json:"value" yaml:"value"`
+}
+
+func (r MessageRecord) Text() string {
+	return fmt.Sprintf("Received message with data: %v, offset: %v", string(r.Value), r.Offset)
+}
+
+// ActivityRecord is the structured form of a message received via
+// subscribe-activity-stream.
+type ActivityRecord struct {
+	Op     string `json:"op" yaml:"op"`
+	Detail string `json:"detail" yaml:"detail"`
+	Offset int64  `json:"offset" yaml:"offset"`
+}
+
+func (r ActivityRecord) Text() string {
+	return fmt.Sprintf("Received activity stream message: op: %v, %v, offset: %v", r.Op, r.Detail, r.Offset)
+}
@@ -0,0 +1,106 @@
+package output
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"gopkg.in/natefinch/lumberjack.v2"
+)
+
+// Sink writes formatted records somewhere: stdout, a rotating file, or an
+// HTTP collector.
+type Sink interface {
+	Write([]byte) error
+	Close() error
+}
+
+// FileSinkOptions control rotation of a file: sink, mirroring the
+// --max-size/--max-backups/--max-age flags.
+type FileSinkOptions struct {
+	// MaxSize is the maximum size in megabytes of the file before it is
+	// rotated.
+	MaxSize int
+	// MaxBackups is the maximum number of rotated files to retain.
+	MaxBackups int
+	// MaxAge is the maximum number of days to retain a rotated file.
+	MaxAge int
+}
+
+type stdoutSink struct{}
+
+func (stdoutSink) Write(data []byte) error {
+	_, err := os.Stdout.Write(data)
+	return err
+}
+
+func (stdoutSink) Close() error {
+	return nil
+}
+
+type fileSink struct {
+	logger *lumberjack.Logger
+}
+
+func newFileSink(path string, opts FileSinkOptions) *fileSink {
+	return &fileSink{logger: &lumberjack.Logger{
+		Filename:   path,
+		MaxSize:    opts.MaxSize,
+		MaxBackups: opts.MaxBackups,
+		MaxAge:     opts.MaxAge,
+	}}
+}
+
+func (s *fileSink) Write(data []byte) error {
+	_, err := s.logger.Write(data)
+	return err
+}
+
+func (s *fileSink) Close() error {
+	return s.logger.Close()
+}
+
+type httpSink struct {
+	url    string
+	client *http.Client
+}
+
+func newHTTPSink(url string) *httpSink {
+	return &httpSink{url: url, client: &http.Client{Timeout: 10 * time.Second}}
+}
+
+func (s *httpSink) Write(data []byte) error {
+	resp, err := s.client.Post(s.url, "application/json", bytes.NewReader(data))
+	if err != nil {
+		return fmt.Errorf("posting record to %v: %w", s.url, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("posting record to %v: unexpected status %v", s.url, resp.Status)
+	}
+
+	return nil
+}
+
+func (s *httpSink) Close() error {
+	return nil
+}
+
+// NewSink builds a Sink from a --sink flag value: "stdout", "file:PATH",
+// or "http(s)://URL".
+func NewSink(spec string, fileOpts FileSinkOptions) (Sink, error) {
+	switch {
+	case spec == "" || spec == "stdout":
+		return stdoutSink{}, nil
+	case strings.HasPrefix(spec, "file:"):
+		return newFileSink(strings.TrimPrefix(spec, "file:"), fileOpts), nil
+	case strings.HasPrefix(spec, "http://"), strings.HasPrefix(spec, "https://"):
+		return newHTTPSink(spec), nil
+	default:
+		return nil, fmt.Errorf("invalid output sink: %v", spec)
+	}
+}
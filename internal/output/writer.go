@@ -0,0 +1,45 @@
+package output
+
+import "fmt"
+
+// Writer formats records with a Formatter and writes them to a Sink.
+type Writer struct {
+	Formatter Formatter
+	Sink      Sink
+}
+
+// NewWriter builds a Writer from an --output format spec and a --sink
+// spec, as accepted by NewFormatter and NewSink respectively.
+func NewWriter(outputSpec, sinkSpec string, fileOpts FileSinkOptions) (*Writer, error) {
+	formatter, err := NewFormatter(outputSpec)
+	if err != nil {
+		return nil, err
+	}
+
+	sink, err := NewSink(sinkSpec, fileOpts)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Writer{Formatter: formatter, Sink: sink}, nil
+}
+
+// WriteRecord formats r and writes it to the underlying sink.
+func (w *Writer) WriteRecord(r Record) error {
+	data, err := w.Formatter.Format(r)
+	if err != nil {
+		return fmt.Errorf("formatting output record: %w", err)
+	}
+
+	if err := w.Sink.Write(data); err != nil {
+		return fmt.Errorf("writing output record: %w", err)
+	}
+
+	return nil
+}
+
+// Close releases any resources held by the underlying sink (e.g. open
+// file handles).
+func (w *Writer) Close() error {
+	return w.Sink.Close()
+}
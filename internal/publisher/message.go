@@ -0,0 +1,93 @@
+package publisher
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+// Message is a single message to publish, read from --from-file,
+// --from-stdin, or constructed from a one-shot --message/--key flag
+// pair.
+type Message struct {
+	Key       []byte
+	Value     []byte
+	Headers   map[string][]byte
+	Partition *int32
+}
+
+// ReadMessages parses r as either newline-delimited raw values ("text")
+// or newline-delimited JSON objects ("json", of the form
+// {"key":"...","value":"...","headers":{...},"partition":N}), sending
+// each as a Message on the returned channel, which is buffered to
+// batchSize so reading can run ahead of a slower publisher. The channel
+// is closed once r is exhausted or a line fails to parse; the error, if
+// any, is sent on the returned error channel before it is closed.
+func ReadMessages(r io.Reader, format string, batchSize int) (<-chan Message, <-chan error) {
+	if batchSize < 1 {
+		batchSize = 1
+	}
+
+	out := make(chan Message, batchSize)
+	errC := make(chan error, 1)
+
+	go func() {
+		defer close(out)
+		defer close(errC)
+
+		scanner := bufio.NewScanner(r)
+		scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+
+		for scanner.Scan() {
+			line := scanner.Bytes()
+			if len(line) == 0 {
+				continue
+			}
+
+			if format == "json" {
+				var raw jsonMessage
+				if err := json.Unmarshal(line, &raw); err != nil {
+					errC <- fmt.Errorf("parsing message: %w", err)
+					return
+				}
+				out <- raw.toMessage()
+				continue
+			}
+
+			value := make([]byte, len(line))
+			copy(value, line)
+			out <- Message{Value: value}
+		}
+
+		if err := scanner.Err(); err != nil {
+			errC <- fmt.Errorf("reading messages: %w", err)
+		}
+	}()
+
+	return out, errC
+}
+
+type jsonMessage struct {
+	Key       string            `json:"key"`
+	Value     string            `json:"value"`
+	Headers   map[string]string `json:"headers"`
+	Partition *int32            `json:"partition"`
+}
+
+func (m jsonMessage) toMessage() Message {
+	msg := Message{Value: []byte(m.Value), Partition: m.Partition}
+
+	if m.Key != "" {
+		msg.Key = []byte(m.Key)
+	}
+
+	if len(m.Headers) > 0 {
+		msg.Headers = make(map[string][]byte, len(m.Headers))
+		for k, v := range m.Headers {
+			msg.Headers[k] = []byte(v)
+		}
+	}
+
+	return msg
+}
@@ -0,0 +1,178 @@
+// Package publisher implements a batched, concurrent publisher for
+// high-throughput producers, built around lift.Client.PublishAsync.
+package publisher
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+
+	lift "github.com/liftbridge-io/go-liftbridge/v2"
+)
+
+// PartitionStrategy controls how a Message without an explicit Partition
+// is routed to a stream partition.
+type PartitionStrategy int
+
+const (
+	// PartitionRoundRobin cycles through partitions in order.
+	PartitionRoundRobin PartitionStrategy = iota
+	// PartitionHashKey routes by a hash of the message key.
+	PartitionHashKey
+	// PartitionManual routes every message to ManualPartition.
+	PartitionManual
+)
+
+// Options configure a Publisher.
+type Options struct {
+	Stream            string
+	Concurrency       int
+	RatePerSecond     float64
+	PartitionStrategy PartitionStrategy
+	ManualPartition   int32
+	AckPolicy         lift.MessageOption
+	// DeadLetterWriter, if set, receives one JSON line per message whose
+	// ack came back with an error.
+	DeadLetterWriter io.Writer
+}
+
+// Publisher publishes messages read from a channel using
+// lift.Client.PublishAsync across a pool of worker goroutines, tracking
+// throughput and ack latency.
+type Publisher struct {
+	client  lift.Client
+	opts    Options
+	stats   *Stats
+	limiter *rateLimiter
+
+	deadLetterMu sync.Mutex
+}
+
+// New builds a Publisher that publishes to opts.Stream via client.
+func New(client lift.Client, opts Options) *Publisher {
+	p := &Publisher{client: client, opts: opts, stats: NewStats()}
+	if opts.RatePerSecond > 0 {
+		p.limiter = newRateLimiter(opts.RatePerSecond)
+	}
+	return p
+}
+
+// Stats returns the publisher's live statistics.
+func (p *Publisher) Stats() *Stats {
+	return p.stats
+}
+
+// Run publishes every message from messages until the channel is closed
+// or ctx is canceled, then waits for any outstanding acks to settle.
+func (p *Publisher) Run(ctx context.Context, messages <-chan Message) {
+	concurrency := p.opts.Concurrency
+	if concurrency < 1 {
+		concurrency = 1
+	}
+
+	var workers sync.WaitGroup
+	var pending sync.WaitGroup
+
+	for i := 0; i < concurrency; i++ {
+		workers.Add(1)
+		go func() {
+			defer workers.Done()
+
+			for {
+				select {
+				case <-ctx.Done():
+					return
+				case m, ok := <-messages:
+					if !ok {
+						return
+					}
+
+					if p.limiter != nil && !p.limiter.wait(ctx) {
+						return
+					}
+
+					pending.Add(1)
+					p.publish(ctx, m, &pending)
+				}
+			}
+		}()
+	}
+
+	workers.Wait()
+	pending.Wait()
+}
+
+func (p *Publisher) publish(ctx context.Context, m Message, pending *sync.WaitGroup) {
+	sentAt := time.Now()
+	p.stats.recordSent()
+
+	err := p.client.PublishAsync(ctx, p.opts.Stream, m.Value, func(ack *lift.Ack, err error) {
+		defer pending.Done()
+
+		if err != nil {
+			p.stats.recordFailed()
+			p.deadLetter(m, err)
+			return
+		}
+
+		p.stats.recordAcked(time.Since(sentAt))
+	}, p.messageOptions(m)...)
+	if err != nil {
+		pending.Done()
+		p.stats.recordFailed()
+		p.deadLetter(m, err)
+	}
+}
+
+func (p *Publisher) messageOptions(m Message) []lift.MessageOption {
+	var opts []lift.MessageOption
+
+	if p.opts.AckPolicy != nil {
+		opts = append(opts, p.opts.AckPolicy)
+	}
+
+	if len(m.Headers) > 0 {
+		opts = append(opts, lift.Headers(m.Headers))
+	}
+
+	if len(m.Key) > 0 {
+		opts = append(opts, lift.Key(m.Key))
+	}
+
+	switch {
+	case m.Partition != nil:
+		opts = append(opts, lift.ToPartition(*m.Partition))
+	case p.opts.PartitionStrategy == PartitionManual:
+		opts = append(opts, lift.ToPartition(p.opts.ManualPartition))
+	case p.opts.PartitionStrategy == PartitionHashKey:
+		opts = append(opts, lift.PartitionByKey())
+	default:
+		opts = append(opts, lift.PartitionByRoundRobin())
+	}
+
+	return opts
+}
+
+func (p *Publisher) deadLetter(m Message, cause error) {
+	if p.opts.DeadLetterWriter == nil {
+		return
+	}
+
+	record := struct {
+		Key   string `json:"key,omitempty"`
+		Value string `json:"value"`
+		Error string `json:"error"`
+	}{Key: string(m.Key), Value: string(m.Value), Error: cause.Error()}
+
+	data, err := json.Marshal(record)
+	if err != nil {
+		return
+	}
+
+	p.deadLetterMu.Lock()
+	fmt.Fprintf(p.opts.DeadLetterWriter, "%s\n", data)
+	p.deadLetterMu.Unlock()
+}
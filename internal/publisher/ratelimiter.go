@@ -0,0 +1,50 @@
+package publisher
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// rateLimiter is a simple token-bucket limiter that paces callers to at
+// most one token per interval.
+type rateLimiter struct {
+	interval time.Duration
+
+	mu   sync.Mutex
+	next time.Time
+}
+
+func newRateLimiter(perSecond float64) *rateLimiter {
+	return &rateLimiter{
+		interval: time.Duration(float64(time.Second) / perSecond),
+		next:     time.Now(),
+	}
+}
+
+// wait blocks until the next token is available, returning false if ctx
+// is canceled first.
+func (r *rateLimiter) wait(ctx context.Context) bool {
+	r.mu.Lock()
+	now := time.Now()
+	if r.next.Before(now) {
+		r.next = now
+	}
+	delay := r.next.Sub(now)
+	r.next = r.next.Add(r.interval)
+	r.mu.Unlock()
+
+	if delay <= 0 {
+		return true
+	}
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
@@ -0,0 +1,38 @@
+package publisher
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestRateLimiterPaces(t *testing.T) {
+	limiter := newRateLimiter(100) // one token per 10ms
+
+	ctx := context.Background()
+	start := time.Now()
+	for i := 0; i < 5; i++ {
+		if !limiter.wait(ctx) {
+			t.Fatalf("wait() returned false, want true")
+		}
+	}
+	elapsed := time.Since(start)
+
+	if elapsed < 40*time.Millisecond {
+		t.Errorf("5 tokens at 100/s took %v, want >= 40ms", elapsed)
+	}
+}
+
+func TestRateLimiterWaitCanceled(t *testing.T) {
+	limiter := newRateLimiter(1) // one token per second
+
+	ctx, cancel := context.WithCancel(context.Background())
+	if !limiter.wait(ctx) {
+		t.Fatalf("first wait() returned false, want true")
+	}
+	cancel()
+
+	if limiter.wait(ctx) {
+		t.Errorf("wait() on canceled ctx returned true, want false")
+	}
+}
@@ -0,0 +1,89 @@
+package publisher
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// maxLatencySamples bounds the in-memory latency reservoir so a
+// long-running, high-throughput publish doesn't grow it without bound.
+const maxLatencySamples = 10000
+
+// Stats tracks a Publisher's live throughput and ack latency.
+type Stats struct {
+	sent, acked, failed int64
+
+	mu        sync.Mutex
+	latencies []time.Duration
+}
+
+// NewStats returns a zeroed Stats.
+func NewStats() *Stats {
+	return &Stats{}
+}
+
+func (s *Stats) recordSent() {
+	atomic.AddInt64(&s.sent, 1)
+}
+
+func (s *Stats) recordAcked(latency time.Duration) {
+	atomic.AddInt64(&s.acked, 1)
+
+	s.mu.Lock()
+	s.latencies = append(s.latencies, latency)
+	if len(s.latencies) > maxLatencySamples {
+		s.latencies = s.latencies[len(s.latencies)-maxLatencySamples:]
+	}
+	s.mu.Unlock()
+}
+
+func (s *Stats) recordFailed() {
+	atomic.AddInt64(&s.failed, 1)
+}
+
+// Snapshot is a point-in-time view of a Publisher's statistics.
+type Snapshot struct {
+	Sent, Acked, Failed int64
+	P50, P95, P99       time.Duration
+}
+
+func (s Snapshot) String() string {
+	return fmt.Sprintf("sent=%d, acked=%d, failed=%d, p50=%v, p95=%v, p99=%v",
+		s.Sent, s.Acked, s.Failed, s.P50, s.P95, s.P99)
+}
+
+// Snapshot returns the current counters and ack latency percentiles
+// computed over the latency reservoir.
+func (s *Stats) Snapshot() Snapshot {
+	s.mu.Lock()
+	latencies := make([]time.Duration, len(s.latencies))
+	copy(latencies, s.latencies)
+	s.mu.Unlock()
+
+	sort.Slice(latencies, func(i, j int) bool { return latencies[i] < latencies[j] })
+
+	return Snapshot{
+		Sent:   atomic.LoadInt64(&s.sent),
+		Acked:  atomic.LoadInt64(&s.acked),
+		Failed: atomic.LoadInt64(&s.failed),
+		P50:    percentile(latencies, 0.50),
+		P95:    percentile(latencies, 0.95),
+		P99:    percentile(latencies, 0.99),
+	}
+}
+
+func percentile(sorted []time.Duration, p float64) time.Duration {
+	if len(sorted) == 0 {
+		return 0
+	}
+
+	idx := int(p * float64(len(sorted)))
+	if idx >= len(sorted) {
+		idx = len(sorted) - 1
+	}
+
+	return sorted[idx]
+}
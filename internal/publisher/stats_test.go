@@ -0,0 +1,53 @@
+package publisher
+
+import (
+	"testing"
+	"time"
+)
+
+func TestPercentile(t *testing.T) {
+	sorted := []time.Duration{
+		10 * time.Millisecond,
+		20 * time.Millisecond,
+		30 * time.Millisecond,
+		40 * time.Millisecond,
+		50 * time.Millisecond,
+	}
+
+	cases := []struct {
+		p    float64
+		want time.Duration
+	}{
+		{0, 10 * time.Millisecond},
+		{0.5, 30 * time.Millisecond},
+		{0.99, 50 * time.Millisecond},
+	}
+
+	for _, c := range cases {
+		if got := percentile(sorted, c.p); got != c.want {
+			t.Errorf("percentile(sorted, %v) = %v, want %v", c.p, got, c.want)
+		}
+	}
+
+	if got := percentile(nil, 0.5); got != 0 {
+		t.Errorf("percentile(nil, 0.5) = %v, want 0", got)
+	}
+}
+
+func TestStatsSnapshot(t *testing.T) {
+	s := NewStats()
+	s.recordSent()
+	s.recordSent()
+	s.recordAcked(10 * time.Millisecond)
+	s.recordAcked(20 * time.Millisecond)
+	s.recordFailed()
+
+	snap := s.Snapshot()
+
+	if snap.Sent != 2 || snap.Acked != 2 || snap.Failed != 1 {
+		t.Fatalf("Snapshot() = %+v, want sent=2 acked=2 failed=1", snap)
+	}
+	if snap.P50 != 20*time.Millisecond {
+		t.Errorf("Snapshot().P50 = %v, want 20ms", snap.P50)
+	}
+}
@@ -0,0 +1,276 @@
+// Package supervisor owns the lifecycle of a lift.Client across the
+// lifetime of a long-running command. It reconnects with exponential
+// backoff and jitter whenever the underlying gRPC connection is lost and
+// re-establishes any registered subscriptions from their last
+// acknowledged offset, similar in spirit to the reconnect handling found
+// in NATS-style bus clients.
+package supervisor
+
+import (
+	"context"
+	"encoding/json"
+	"math/rand"
+	"os"
+	"sync"
+	"time"
+
+	lift "github.com/liftbridge-io/go-liftbridge/v2"
+)
+
+const (
+	minBackoff = 500 * time.Millisecond
+	maxBackoff = 30 * time.Second
+)
+
+// Dialer establishes a new connection to a Liftbridge broker. It is
+// called once up front and again after every disconnect.
+type Dialer func() (lift.Client, error)
+
+// Subscription describes a stream subscription that the Supervisor keeps
+// alive across reconnects.
+type Subscription struct {
+	Stream    string
+	Partition int32
+	Handler   func(*lift.Message)
+	Options   []lift.SubscriptionOption
+}
+
+type cursorKey struct {
+	Stream    string
+	Partition int32
+}
+
+// Supervisor dials a lift.Client via Dialer and keeps it alive until its
+// Run context is canceled, automatically reconnecting with exponential
+// backoff and jitter and resuming registered subscriptions from their
+// last acknowledged offset.
+type Supervisor struct {
+	dial       Dialer
+	cursorPath string
+
+	mu            sync.Mutex
+	subscriptions []Subscription
+
+	cursorMu sync.Mutex
+	cursors  map[cursorKey]int64
+
+	// OnDisconnected is called whenever the broker connection is lost,
+	// before a reconnect is attempted.
+	OnDisconnected func(err error)
+	// OnReconnected is called once a dropped connection has been
+	// re-established.
+	OnReconnected func()
+	// OnClosed is called once Run returns because its context was
+	// canceled, after cursors have been flushed.
+	OnClosed func()
+}
+
+// New creates a Supervisor that dials connections with dial and persists
+// subscription offsets to cursorPath. An empty cursorPath disables cursor
+// persistence.
+func New(dial Dialer, cursorPath string) *Supervisor {
+	s := &Supervisor{
+		dial:       dial,
+		cursorPath: cursorPath,
+		cursors:    make(map[cursorKey]int64),
+	}
+	s.loadCursors()
+	return s
+}
+
+// Register adds a subscription to be (re-)established on every connect.
+func (s *Supervisor) Register(sub Subscription) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.subscriptions = append(s.subscriptions, sub)
+}
+
+// Run connects to the broker and keeps the connection alive until ctx is
+// canceled, reconnecting with exponential backoff and jitter whenever the
+// connection is lost. It returns ctx.Err() once the context is canceled,
+// after flushing cursors to disk.
+func (s *Supervisor) Run(ctx context.Context) error {
+	defer func() {
+		_ = s.flushCursors()
+		if s.OnClosed != nil {
+			s.OnClosed()
+		}
+	}()
+
+	attempt := 0
+	connected := false
+	for {
+		client, err := s.dial()
+		if err != nil {
+			attempt++
+			if !s.wait(ctx, attempt) {
+				return ctx.Err()
+			}
+			continue
+		}
+
+		if connected && s.OnReconnected != nil {
+			s.OnReconnected()
+		}
+		connected = true
+		attempt = 0
+
+		err = s.runSubscriptions(ctx, client)
+		client.Close()
+
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+
+		if s.OnDisconnected != nil {
+			s.OnDisconnected(err)
+		}
+
+		attempt++
+		if !s.wait(ctx, attempt) {
+			return ctx.Err()
+		}
+	}
+}
+
+// runSubscriptions (re-)establishes every registered subscription against
+// client and blocks until one of them errors out or ctx is canceled.
+func (s *Supervisor) runSubscriptions(ctx context.Context, client lift.Client) error {
+	s.mu.Lock()
+	subs := make([]Subscription, len(s.subscriptions))
+	copy(subs, s.subscriptions)
+	s.mu.Unlock()
+
+	errC := make(chan error, len(subs))
+
+	for _, sub := range subs {
+		sub := sub
+
+		offset, hasCursor := s.loadCursor(sub.Stream, sub.Partition)
+		opts := subscribeOptions(sub, offset, hasCursor)
+
+		err := client.Subscribe(ctx, sub.Stream, func(m *lift.Message, err error) {
+			if err != nil {
+				select {
+				case errC <- err:
+				default:
+				}
+				return
+			}
+
+			sub.Handler(m)
+			s.saveCursor(sub.Stream, sub.Partition, m.Offset())
+		}, opts...)
+		if err != nil {
+			return err
+		}
+	}
+
+	select {
+	case <-ctx.Done():
+		return nil
+	case err := <-errC:
+		return err
+	}
+}
+
+// subscribeOptions builds the options to (re-)establish sub with. lift.
+// Subscribe has a single StartPosition setting and applies options in
+// order, last one wins. A resumed cursor offset must therefore come after
+// sub.Options so it overrides any static --start-at the caller registered;
+// the earliest-received fallback, by contrast, must come before so the
+// caller's own choice still wins when there is no cursor to resume from.
+func subscribeOptions(sub Subscription, offset int64, hasCursor bool) []lift.SubscriptionOption {
+	var opts []lift.SubscriptionOption
+	if hasCursor {
+		opts = append(opts, sub.Options...)
+		opts = append(opts, lift.StartAtOffset(offset))
+	} else {
+		opts = append(opts, lift.StartAtEarliestReceived())
+		opts = append(opts, sub.Options...)
+	}
+	return opts
+}
+
+func (s *Supervisor) wait(ctx context.Context, attempt int) bool {
+	select {
+	case <-ctx.Done():
+		return false
+	case <-time.After(backoffDuration(attempt)):
+		return true
+	}
+}
+
+// backoffDuration returns an exponentially increasing delay, capped at
+// maxBackoff, with up to 50% jitter to avoid reconnect storms against the
+// same broker.
+func backoffDuration(attempt int) time.Duration {
+	d := minBackoff * time.Duration(1<<uint(attempt))
+	if d <= 0 || d > maxBackoff {
+		d = maxBackoff
+	}
+
+	jitter := time.Duration(rand.Int63n(int64(d)/2 + 1))
+	return d/2 + jitter
+}
+
+func (s *Supervisor) loadCursor(stream string, partition int32) (int64, bool) {
+	s.cursorMu.Lock()
+	defer s.cursorMu.Unlock()
+	offset, ok := s.cursors[cursorKey{stream, partition}]
+	return offset, ok
+}
+
+func (s *Supervisor) saveCursor(stream string, partition int32, offset int64) {
+	s.cursorMu.Lock()
+	s.cursors[cursorKey{stream, partition}] = offset
+	s.cursorMu.Unlock()
+}
+
+func (s *Supervisor) loadCursors() {
+	if s.cursorPath == "" {
+		return
+	}
+
+	data, err := os.ReadFile(s.cursorPath)
+	if err != nil {
+		return
+	}
+
+	var entries []cursorEntry
+	if err := json.Unmarshal(data, &entries); err != nil {
+		return
+	}
+
+	s.cursorMu.Lock()
+	defer s.cursorMu.Unlock()
+	for _, e := range entries {
+		s.cursors[cursorKey{e.Stream, e.Partition}] = e.Offset
+	}
+}
+
+type cursorEntry struct {
+	Stream    string `json:"stream"`
+	Partition int32  `json:"partition"`
+	Offset    int64  `json:"offset"`
+}
+
+func (s *Supervisor) flushCursors() error {
+	if s.cursorPath == "" {
+		return nil
+	}
+
+	s.cursorMu.Lock()
+	entries := make([]cursorEntry, 0, len(s.cursors))
+	for k, offset := range s.cursors {
+		entries = append(entries, cursorEntry{Stream: k.Stream, Partition: k.Partition, Offset: offset})
+	}
+	s.cursorMu.Unlock()
+
+	data, err := json.Marshal(entries)
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(s.cursorPath, data, 0o644)
+}
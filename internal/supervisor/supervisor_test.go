@@ -0,0 +1,137 @@
+package supervisor
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	lift "github.com/liftbridge-io/go-liftbridge/v2"
+)
+
+func TestSubscribeOptionsPrefersCursorOverStaticStartAt(t *testing.T) {
+	sub := Subscription{
+		Stream:  "orders",
+		Options: []lift.SubscriptionOption{lift.StartAtEarliestReceived()},
+	}
+
+	opts := subscribeOptions(sub, 42, true)
+
+	var got lift.SubscriptionOptions
+	for _, opt := range opts {
+		if err := opt(&got); err != nil {
+			t.Fatalf("applying option: %v", err)
+		}
+	}
+
+	if got.StartPosition != lift.StartPosition(1) || got.StartOffset != 42 {
+		// lift.StartAtOffset sets proto.StartPosition_OFFSET (1) and
+		// StartOffset; a regression of the ordering bug would instead
+		// leave StartPosition at whatever sub.Options set (earliest).
+		t.Fatalf("cursor offset was overridden by the caller's static start option: %+v", got)
+	}
+}
+
+func TestSubscribeOptionsFallsBackToCallerStartAtWithoutCursor(t *testing.T) {
+	sub := Subscription{
+		Stream:  "orders",
+		Options: []lift.SubscriptionOption{lift.StartAtNewOnly()},
+	}
+
+	opts := subscribeOptions(sub, 0, false)
+
+	var got lift.SubscriptionOptions
+	for _, opt := range opts {
+		if err := opt(&got); err != nil {
+			t.Fatalf("applying option: %v", err)
+		}
+	}
+
+	if got.StartPosition != lift.StartPosition(0) {
+		// proto.StartPosition_NEW_ONLY == 0; the earliest-received
+		// fallback must not clobber it when there's nothing to resume.
+		t.Fatalf("caller's start option was overridden by the earliest-received fallback: %+v", got)
+	}
+}
+
+// backoffDuration's jitter makes exact values non-deterministic, but for a
+// given attempt the result is always within [d/2, d] where d is the
+// (possibly capped) exponential delay; these tests check that bound
+// directly instead of asserting on an exact value.
+func TestBackoffDurationBounds(t *testing.T) {
+	for attempt := 1; attempt <= 10; attempt++ {
+		raw := minBackoff * time.Duration(1<<uint(attempt))
+		if raw <= 0 || raw > maxBackoff {
+			raw = maxBackoff
+		}
+		lower, upper := raw/2, raw
+
+		for i := 0; i < 20; i++ {
+			d := backoffDuration(attempt)
+			if d < lower || d > upper {
+				t.Fatalf("attempt %d: backoffDuration() = %v, want within [%v, %v]", attempt, d, lower, upper)
+			}
+		}
+	}
+}
+
+func TestBackoffDurationGrowsBeforeCap(t *testing.T) {
+	const samples = 50
+	avg := func(attempt int) time.Duration {
+		var total time.Duration
+		for i := 0; i < samples; i++ {
+			total += backoffDuration(attempt)
+		}
+		return total / samples
+	}
+
+	if early, later := avg(1), avg(4); early >= later {
+		t.Errorf("average backoff did not grow from attempt 1 (%v) to attempt 4 (%v)", early, later)
+	}
+}
+
+func TestBackoffDurationCapsAtMax(t *testing.T) {
+	for i := 0; i < 20; i++ {
+		if d := backoffDuration(20); d > maxBackoff {
+			t.Fatalf("backoffDuration(20) = %v, want <= maxBackoff (%v)", d, maxBackoff)
+		}
+	}
+}
+
+func TestCursorRoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "cursors.json")
+
+	s := New(func() (lift.Client, error) { return nil, nil }, path)
+	s.saveCursor("orders", 2, 17)
+	s.saveCursor("orders", 3, 99)
+
+	if err := s.flushCursors(); err != nil {
+		t.Fatalf("flushCursors: %v", err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("reading cursor file: %v", err)
+	}
+
+	var entries []cursorEntry
+	if err := json.Unmarshal(data, &entries); err != nil {
+		t.Fatalf("unmarshaling cursor file: %v", err)
+	}
+	if len(entries) != 2 {
+		t.Fatalf("got %d cursor entries, want 2", len(entries))
+	}
+
+	reloaded := New(func() (lift.Client, error) { return nil, nil }, path)
+
+	if offset, ok := reloaded.loadCursor("orders", 2); !ok || offset != 17 {
+		t.Fatalf("loadCursor(orders, 2) = (%v, %v), want (17, true)", offset, ok)
+	}
+	if offset, ok := reloaded.loadCursor("orders", 3); !ok || offset != 99 {
+		t.Fatalf("loadCursor(orders, 3) = (%v, %v), want (99, true)", offset, ok)
+	}
+	if _, ok := reloaded.loadCursor("orders", 0); ok {
+		t.Fatalf("loadCursor(orders, 0) found a cursor that was never saved under that partition")
+	}
+}